@@ -0,0 +1,36 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+)
+
+//negative number text such as -1,234.5, the minus is followed by a digit
+func isNegative(val string) bool {
+	if len(val) < 2 || val[0] != '-' || !isDigit(val[1]) {
+		return false
+	}
+	_, err := strconv.ParseFloat(strings.Replace(val[1:], ",", "", -1), 64)
+	return err == nil
+}
+
+//negative number in parentheses of Accounting
+func (this *state) accounting(val string) string {
+	if this.Accounting && isNegative(val) {
+		return "(" + val[1:] + ")"
+	}
+	return val
+}
+
+//mark negative data cells with NegativeStyle
+func (this *state) negatives(head int) func(row, col int, val string) Style {
+	return func(row, col int, val string) Style {
+		if row < head {
+			return ""
+		}
+		if isNegative(val) || this.Accounting && len(val) > 2 && val[0] == '(' && isNegative("-"+strings.TrimSuffix(val[1:], ")")) {
+			return this.NegativeStyle
+		}
+		return ""
+	}
+}
@@ -0,0 +1,66 @@
+package table
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//ascii of Latin-1 letters from U+00C0
+var latin1 = [...]string{
+	"A", "A", "A", "A", "A", "A", "AE", "C", "E", "E", "E", "E", "I", "I", "I", "I",
+	"D", "N", "O", "O", "O", "O", "O", "x", "O", "U", "U", "U", "U", "Y", "TH", "ss",
+	"a", "a", "a", "a", "a", "a", "ae", "c", "e", "e", "e", "e", "i", "i", "i", "i",
+	"d", "n", "o", "o", "o", "o", "o", "/", "o", "u", "u", "u", "u", "y", "th", "y",
+}
+
+//ascii of Latin Extended-A letters from U+0100, ligatures are in asciiRunes
+const latinExtA = "AaAaAaCcCcCcCcDdDdEeEeEeEeEeGgGgGgGgHhHhIiIiIiIiIi??JjKkkLlLlLlLlLlNnNnNnnNnOoOoOo??RrRrRrSsSsSsSsTtTtTtUuUuUuUuUuUuWwYyYZzZzZzs"
+
+//ascii of punctuation, symbols, sparkline blocks and box drawing
+var asciiRunes = map[rune]string{
+	'Ĳ': "IJ", 'ĳ': "ij", 'Œ': "OE", 'œ': "oe",
+	' ': " ", '¡': "!", '¢': "c", '£': "GBP", '¥': "JPY", '€': "EUR", '©': "(c)", '®': "(R)",
+	'«': "<<", '»': ">>", '°': "o", '±': "+-", '·': ".", '¿': "?", '×': "x", '÷': "/",
+	'‘': "'", '’': "'", '‚': "'", '“': "\"", '”': "\"", '„': "\"", '‐': "-", '–': "-", '—': "-",
+	'…': "...", '•': "*", '→': "->", '←': "<-", '✓': "v", '✔': "v", '✗': "x", '✘': "x",
+	'▁': "_", '▂': ".", '▃': "-", '▄': "-", '▅': "=", '▆': "+", '▇': "*", '█': "#",
+	'─': "-", '━': "-", '│': "|", '┃': "|", '┌': "+", '┐': "+", '└': "+", '┘': "+",
+	'├': "+", '┤': "+", '┬': "+", '┴': "+", '┼': "+",
+}
+
+//ascii approximation of str, combining marks are dropped and other characters become ?
+func transliterate(str string) string {
+	if isASCIIText(str) {
+		return str
+	}
+
+	var buf strings.Builder
+	buf.Grow(len(str))
+	for _, c := range str {
+		switch {
+		case c < utf8.RuneSelf:
+			buf.WriteRune(c)
+		case asciiRunes[c] != "":
+			buf.WriteString(asciiRunes[c])
+		case c >= 0xc0 && c < 0x100:
+			buf.WriteString(latin1[c-0xc0])
+		case c >= 0x100 && c < 0x180:
+			buf.WriteByte(latinExtA[c-0x100])
+		case unicode.Is(unicode.Mn, c):
+		default:
+			buf.WriteByte('?')
+		}
+	}
+	return buf.String()
+}
+
+//str has only ascii bytes
+func isASCIIText(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if str[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package table
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+	bigRatType   = reflect.TypeOf(big.Rat{})
+)
+
+//math/big number type
+func isBig(t reflect.Type) bool {
+	return t == bigIntType || t == bigFloatType || t == bigRatType
+}
+
+//text of non-nil big number with BigPrecision and BigGrouping
+func (this *state) formatBig(v reflect.Value) (str string, ok bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if !isBig(v.Type()) {
+		return "", false
+	}
+
+	//methods have pointer receivers
+	if !v.CanAddr() {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p.Elem()
+	}
+
+	switch n := v.Addr().Interface().(type) {
+	case *big.Int:
+		str = n.String()
+	case *big.Float:
+		str = n.String()
+		if this.BigPrecision >= 0 {
+			str = n.Text('f', this.BigPrecision)
+		}
+	case *big.Rat:
+		str = n.String()
+		if this.BigPrecision >= 0 {
+			str = n.FloatString(this.BigPrecision)
+		}
+	}
+	return groupDigits(str, this.BigGrouping), true
+}
+
+//separate thousands of integer parts with sep, fractions are kept
+func groupDigits(str, sep string) string {
+	if sep == "" {
+		return str
+	}
+
+	var buf strings.Builder
+	for i := 0; i < len(str); {
+		if !isDigit(str[i]) {
+			buf.WriteByte(str[i])
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(str) && isDigit(str[j]) {
+			j++
+		}
+		digits := str[i:j]
+
+		//fraction and exponent digits are not grouped
+		frac := i > 0 && (str[i-1] == '.' || isExponent(str[i-1]) ||
+			i > 1 && (str[i-1] == '+' || str[i-1] == '-') && isExponent(str[i-2]))
+		for k := range digits {
+			if !frac && k != 0 && (len(digits)-k)%3 == 0 {
+				buf.WriteString(sep)
+			}
+			buf.WriteByte(digits[k])
+		}
+		i = j
+	}
+	return buf.String()
+}
+
+//ascii digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+//exponent mark of float text
+func isExponent(c byte) bool {
+	return c == 'e' || c == 'E'
+}
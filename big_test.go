@@ -0,0 +1,48 @@
+package table
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBig(t *testing.T) {
+	type Account struct {
+		Name    string
+		Balance big.Int
+		Rate    *big.Float
+		Share   *big.Rat
+	}
+
+	n, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	accounts := []Account{
+		{"a", *n, big.NewFloat(1234.5), big.NewRat(1, 3)},
+		{"b", *big.NewInt(-42), nil, big.NewRat(12345, 1)},
+	}
+
+	expected := "" +
+		"    Name             Balance               Rate    Share  \n" +
+		" 1   a    123456789012345678901234567890  1234.5    1/3   \n" +
+		" 2   b                 -42                        12345/1 \n"
+	if out := Format(accounts, WithTheme(ThemePlain)); out != expected {
+		t.Errorf("default:\n%s", out)
+	}
+
+	expected = "" +
+		"    Name                  Balance                    Rate      Share   \n" +
+		" 1   a    123,456,789,012,345,678,901,234,567,890  1,234.50    0.33    \n" +
+		" 2   b                      -42                              12,345.00 \n"
+	out := Format(accounts, WithTheme(ThemePlain), func(o *Options) {
+		o.BigPrecision = 2
+		o.BigGrouping = ","
+	})
+	if out != expected {
+		t.Errorf("precision and grouping:\n%s", out)
+	}
+
+	if out := Format(n); out != Format(n.String()) {
+		t.Errorf("single:\n%s", out)
+	}
+	if s := groupDigits("-1234567.891e+1234", ","); s != "-1,234,567.891e+1234" {
+		t.Errorf("group: %s", s)
+	}
+}
@@ -0,0 +1,72 @@
+package table
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"unicode"
+	"unicode/utf8"
+)
+
+//encoding of binary data
+type BinaryMode int
+
+const (
+	//[]byte as numbers and strings as text
+	BinaryRaw BinaryMode = iota
+	//hex digits such as 00ff1b
+	BinaryHex
+	//standard base64 such as AP8b
+	BinaryBase64
+)
+
+//text of []byte or string with non-printable bytes by BinaryEncoding
+func (this *state) formatBinary(v reflect.Value) (str string, ok bool) {
+	if this.BinaryEncoding == BinaryRaw {
+		return "", false
+	}
+
+	for v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	var data []byte
+	switch {
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		data = v.Bytes()
+	case v.Kind() == reflect.String && isBinary(v.String()):
+		data = []byte(v.String())
+	default:
+		return "", false
+	}
+
+	//cut long data
+	size := len(data)
+	if this.BinaryMaxBytes > 0 && size > this.BinaryMaxBytes {
+		data = data[:this.BinaryMaxBytes]
+	}
+
+	if this.BinaryEncoding == BinaryBase64 {
+		str = base64.StdEncoding.EncodeToString(data)
+	} else {
+		str = hex.EncodeToString(data)
+	}
+	if len(data) < size {
+		str = fmt.Sprintf("%s%s (%d bytes)", str, ellipsis, size)
+	}
+	return str, true
+}
+
+//string with invalid utf8 or control characters other than spaces
+func isBinary(str string) bool {
+	if !utf8.ValidString(str) {
+		return true
+	}
+	for _, c := range str {
+		if unicode.IsControl(c) && !unicode.IsSpace(c) {
+			return true
+		}
+	}
+	return false
+}
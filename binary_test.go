@@ -0,0 +1,36 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBinary(t *testing.T) {
+	type Blob struct {
+		Key  string
+		Data []byte
+	}
+	blobs := []Blob{{"a", []byte{0, 0xff, 0x1b}}, {"b\x00c", []byte(strings.Repeat("x", 40))}}
+	binary := func(m BinaryMode) Option {
+		return func(o *Options) {
+			o.UseBoard = false
+			o.BinaryEncoding = m
+			o.BinaryMaxBytes = 4
+		}
+	}
+
+	cases := map[BinaryMode]string{
+		BinaryHex:    "     Key            Data         \n 1    a            00ff1b        \n 2  620063  78787878… (40 bytes) \n",
+		BinaryBase64: "    Key           Data         \n 1   a            AP8b         \n 2  YgBj  eHh4eA==… (40 bytes) \n",
+	}
+	for m, expected := range cases {
+		if str := Format(blobs, binary(m)); str != expected {
+			t.Errorf("mode %d:\n%q", m, str)
+		}
+	}
+
+	//raw by default
+	if str := Format(blobs[0], WithTheme(ThemePlain)); !strings.Contains(str, "[0 255 27]") {
+		t.Errorf("raw:\n%q", str)
+	}
+}
@@ -0,0 +1,22 @@
+package table
+
+//texts of true and false
+type BoolStyle [2]string
+
+//predefined bool styles
+var (
+	BoolCheck = BoolStyle{"✓", "✗"}
+	BoolYesNo = BoolStyle{"yes", "no"}
+	BoolOnOff = BoolStyle{"on", "off"}
+)
+
+//text of b, empty style means true and false
+func (this BoolStyle) text(b bool) (str string, ok bool) {
+	if this == (BoolStyle{}) {
+		return "", false
+	}
+	if b {
+		return this[0], true
+	}
+	return this[1], true
+}
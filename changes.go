@@ -0,0 +1,51 @@
+package table
+
+//style of changed cells when ChangeStyle is empty
+const defaultChangeStyle Style = "1;33"
+
+//encode prev and obj, format obj with changed cells marked, cells are compared at their positions
+//before sorting, sampling and other layout
+func (this *state) runChanges(prev, obj interface{}) string {
+	if this.SubTables {
+		if str, ok := this.runSubTables(obj); ok {
+			return str
+		}
+	}
+	if this.Renderer != nil {
+		return this.runRenderer(obj)
+	}
+	old := &state{Options: this.Options}
+	old.encode(prev)
+	cells := old.cells
+
+	//layout cuts and moves cells
+	this.encode(obj)
+	encoded := make([][]string, len(this.cells))
+	for row, line := range this.cells {
+		encoded[row] = append([]string{}, line...)
+	}
+	this.rowOrigins = make([]int, len(this.cells))
+	for row := range this.rowOrigins {
+		this.rowOrigins[row] = row
+	}
+	this.colOrigins = make([]int, this.colNum)
+	for col := range this.colOrigins {
+		this.colOrigins[col] = col
+	}
+
+	style := this.ChangeStyle
+	if style == "" {
+		style = defaultChangeStyle
+	}
+	this.marks = append(this.marks, func(row, col int, val string) Style {
+		row, col = this.origin(row, col)
+		if row < 0 || col < 0 {
+			return ""
+		}
+		if row < len(cells) && col < len(cells[row]) && cells[row][col] == cellOf(encoded[row], col) {
+			return ""
+		}
+		return style
+	})
+	return this.format()
+}
@@ -0,0 +1,44 @@
+package table
+
+import (
+	"testing"
+)
+
+//changed cells are styled
+func TestFormatChanges(t *testing.T) {
+	type Host struct {
+		Name string
+		Load int
+	}
+	prev := []Host{{"a", 1}, {"b", 2}}
+	cur := []Host{{"a", 1}, {"b", 5}, {"c", 3}}
+	plain := func(o *Options) { o.UseBoard = false }
+
+	str := FormatChanges(prev, cur, plain, func(o *Options) { o.ChangeStyle = "7" })
+	expected := "" +
+		"    Name  Load \n" +
+		" 1   a     1   \n" +
+		" 2   b     \x1b[7m5\x1b[0m   \n" +
+		" \x1b[7m3\x1b[0m   \x1b[7mc\x1b[0m     \x1b[7m3\x1b[0m   \n"
+	if str != expected {
+		t.Errorf("changes:\n%q", str)
+	}
+
+	//cells are compared before sorting and eliding
+	prev = []Host{{"b", 2}, {"a", 1}}
+	cur = []Host{{"b", 2}, {"a", 1}, {"c", 7}}
+	str = FormatChanges(prev, cur, plain, WithSort("Name"), WithHeadTail(1, 1), func(o *Options) { o.ChangeStyle = "7" })
+	expected = "" +
+		"    Name    Load   \n" +
+		" 2   a       1     \n" +
+		" … 1 row omitted … \n" +
+		" \x1b[7m3\x1b[0m   \x1b[7mc\x1b[0m       \x1b[7m7\x1b[0m     \n"
+	if str != expected {
+		t.Errorf("sorted:\n%q", str)
+	}
+
+	//no change, same as Format
+	if FormatChanges(cur, cur) != Format(cur) {
+		t.Errorf("unchanged table is styled")
+	}
+}
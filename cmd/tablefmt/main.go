@@ -0,0 +1,130 @@
+//tablefmt reads CSV, TSV, JSON or logfmt from stdin and prints a formatted table, such as
+//	curl -s api/users | tablefmt -in json -style markdown -align left
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/fanzhidongyzby/table"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "tablefmt:", err)
+		os.Exit(1)
+	}
+}
+
+//parse flags, read input and write table
+func run(args []string, in io.Reader, out io.Writer) error {
+	flags := flag.NewFlagSet("tablefmt", flag.ContinueOnError)
+	input := flags.String("in", "csv", "input format: csv, tsv, json or logfmt")
+	style := flags.String("style", "box", "table style: plain, box, light, dark, compact, ascii or markdown")
+	align := flags.String("align", "", "cell alignment: left, center or right, empty means the style's")
+	maxWidth := flags.Int("maxwidth", 0, "cut cells of text output wider than maxwidth, 0 means no limit")
+	output := flags.String("out", "text", "output format: text, html, csv or tsv")
+	noFormulas := flags.Bool("noformulas", false, "prefix csv and tsv cells starting with = + - @ with an apostrophe")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	theme, ok := table.Themes[*style]
+	if !ok {
+		return fmt.Errorf("unknown style %q", *style)
+	}
+	switch *align {
+	case "":
+	case "left":
+		theme.Align = table.AlignLeft
+	case "center":
+		theme.Align = table.AlignCenter
+	case "right":
+		theme.Align = table.AlignRight
+	default:
+		return fmt.Errorf("unknown alignment %q", *align)
+	}
+
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	//colors are dropped when piped
+	opts := []table.Option{table.WithTheme(theme), table.WithOutput(out, nil), table.WithMaxWidth(*maxWidth), func(o *table.Options) {
+		o.EscapeFormulas = *noFormulas
+	}}
+
+	//records are shown as they are, json is encoded
+	var records [][]string
+	var t *table.Table
+	switch *input {
+	case "csv":
+		records, err = readCSV(data, ',')
+	case "tsv":
+		records, err = readCSV(data, '\t')
+	case "json":
+		var obj interface{}
+		if obj, err = readJSON(data); err == nil {
+			t, err = table.Encode(obj, opts...)
+		}
+	case "logfmt":
+		records, err = table.ReadLogfmt(bytes.NewReader(data))
+	default:
+		err = fmt.Errorf("unknown input format %q", *input)
+	}
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		if len(records) == 0 || len(records[0]) == 0 {
+			return errors.New("no input")
+		}
+		t = table.NewTable(records[0], records[1:], opts...)
+	}
+
+	//registered output formats
+	renderer, ok := table.RendererOf(*output)
+	if !ok {
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+	return renderer.Render(t, out)
+}
+
+//read csv records, rows may have different lengths
+func readCSV(data []byte, comma rune) ([][]string, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = comma
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	return r.ReadAll()
+}
+
+//read json, a list of objects becomes rows with union of keys as columns
+func readJSON(data []byte) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	list, ok := v.([]interface{})
+	if !ok {
+		return v, nil
+	}
+	rows := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		row, ok := item.(map[string]interface{})
+		if !ok {
+			return list, nil
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
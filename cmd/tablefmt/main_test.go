@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+//csv, tsv and json input
+func TestRun(t *testing.T) {
+	cases := []struct {
+		args     []string
+		in       string
+		expected string
+	}{
+		{[]string{"-style", "plain"}, "a,b\n1,\n", " a  b \n 1    \n"},
+		{[]string{"-in", "tsv", "-style", "markdown"}, "name\tage\nalice\t30\n",
+			"| name  | age |\n|-------|-----|\n| alice | 30  |\n"},
+		{[]string{"-style", "plain", "-maxwidth", "3"}, "abcdef\n", " ab… \n"},
+		{[]string{"-style", "plain", "-maxwidth", "4"}, "中文中文\n", " 中…  \n"},
+		{[]string{"-in", "json", "-style", "plain", "-maxwidth", "3"}, `[{"a":"abcdef"}]`, "     a  \n 1  ab… \n"},
+		{[]string{"-in", "json", "-style", "plain", "-align", "left"}, `[{"b":1,"a":"x"},{"a":"y"}]`,
+			"    a  b \n 1  x  1 \n 2  y    \n"},
+		{[]string{"-in", "logfmt", "-style", "plain"}, "a=1 b=\"x y\"\nb=2\n", " a   b  \n 1  x y \n     2  \n"},
+		{[]string{"-out", "csv", "-noformulas"}, "a,b\n=1+1,-2\n", "a,b\n'=1+1,-2\n"},
+		//fields are kept as they are
+		{[]string{"-style", "plain"}, "a,b\n_,x\n,y\n", " a  b \n _  x \n    y \n"},
+		{[]string{"-out", "csv"}, "a,b\n1\x1f2,x\n", "a,b\n1\x1f2,x\n"},
+	}
+	for _, c := range cases {
+		var out bytes.Buffer
+		if err := run(c.args, strings.NewReader(c.in), &out); err != nil {
+			t.Errorf("%v: %v", c.args, err)
+		} else if out.String() != c.expected {
+			t.Errorf("%v:\n%q", c.args, out.String())
+		}
+	}
+
+	if err := run([]string{"-style", "none"}, strings.NewReader(""), &bytes.Buffer{}); err == nil {
+		t.Errorf("unknown style accepted")
+	}
+}
@@ -0,0 +1,44 @@
+package table
+
+//column of table, Value computes the column from the element of struct list or map, such as T or *T,
+//the other fields are rules of Schema
+type Column struct {
+	Name  string
+	Value func(row interface{}) string
+
+	//alignment of the column's cells, AlignDefault keeps the theme's alignment
+	Align Align
+	//width without padding, longer cells are cut, 0 means the content decides
+	Width int
+	//text shown instead of data cell, nil means the cell
+	Format func(cell string) string
+	//footer function like footer tags, sum, avg, count or last
+	Footer string
+	//type of data cells, a schema with typed columns validates rows, TypeString means any text
+	Type ColumnType
+}
+
+//add column calculated from every element of struct list or map, after the fields
+func WithComputedColumn(name string, value func(row interface{}) string) Option {
+	return func(this *Options) {
+		this.Computed = append(this.Computed[:len(this.Computed):len(this.Computed)], Column{Name: name, Value: value})
+	}
+}
+
+//names of computed columns
+func (this *state) computedKeys() []string {
+	keys := make([]string, len(this.Computed))
+	for i, c := range this.Computed {
+		keys[i] = c.Name
+	}
+	return keys
+}
+
+//computed values of element
+func (this *state) computedVals(row interface{}) []string {
+	vals := make([]string, len(this.Computed))
+	for i, c := range this.Computed {
+		vals[i] = c.Value(row)
+	}
+	return vals
+}
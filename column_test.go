@@ -0,0 +1,150 @@
+package table
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+//computed columns of struct list and map
+func TestComputedColumn(t *testing.T) {
+	type Score struct {
+		Name string
+		Hit  int
+		All  int
+	}
+	ratio := WithComputedColumn("Ratio", func(row interface{}) string {
+		s := row.(*Score)
+		return strconv.Itoa(s.Hit*100/s.All) + "%"
+	})
+	plain := func(o *Options) { o.UseBoard = false }
+
+	str := Format([]*Score{{"a", 1, 4}, nil}, ratio, plain)
+	expected := "" +
+		"    Name  Hit  All  Ratio \n" +
+		" 1   a     1    4    25%  \n" +
+		" 2                        \n"
+	if str != expected {
+		t.Errorf("list:\n%q", str)
+	}
+
+	str = Format(map[string]*Score{"x": {"b", 3, 4}}, ratio, WithColumns("Ratio"), plain)
+	if str != "    Ratio \n x   75%  \n" {
+		t.Errorf("map:\n%q", str)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	schema := WithSchema(Schema{
+		{Name: "Item", Align: AlignLeft, Footer: "count"},
+		{Name: "Price", Align: AlignRight, Format: func(cell string) string { return "$" + cell }, Footer: "sum"},
+		{Name: "Note", Width: 4},
+	})
+	plain := WithTheme(ThemePlain)
+
+	//grid with header, such as sql rows
+	rows := "Item Price Note\npen 1.5 blue\nnotebook 12 a5-ruled\n"
+	expected := "" +
+		" Item      Price  Note \n" +
+		" pen        $1.5  blue \n" +
+		" notebook    $12  a5-… \n" +
+		" 2         $13.5       \n"
+	if str := Format(rows, plain, schema); str != expected {
+		t.Errorf("grid:\n%q", str)
+	}
+
+	//structs
+	type Order struct {
+		Item  string
+		Price float64
+	}
+	if str := Format([]Order{{"pen", 1.5}}, plain, schema); str != "    Item  Price \n 1  pen    $1.5 \n    1      $1.5 \n" {
+		t.Errorf("struct:\n%q", str)
+	}
+
+	//columns of headless grid by index
+	headless := Schema{{Align: AlignRight}, {Format: strings.ToUpper}}
+	if str := Format("_ _\n1 a\n22 b", plain, WithSchema(headless)); str != "  1  A \n 22  B \n" {
+		t.Errorf("headless:\n%q", str)
+	}
+
+	//AlignCenter centers cells of left aligned theme, AlignDefault keeps it
+	centered := Schema{{Name: "a"}, {Name: "b", Align: AlignCenter}}
+	if str := Format("a b\nlong long", WithTheme(ThemeCompact), WithSchema(centered)); str != " a    │  b   \n──────┼──────\n long │ long \n" {
+		t.Errorf("center:\n%q", str)
+	}
+}
+
+func TestLayout(t *testing.T) {
+	rows := "Item Qty Price Note\npen 2 1.5 blue\nnotebook 12 10 a5\n"
+	compact := WithTheme(ThemeCompact)
+
+	//c centers cells of left aligned theme
+	expected := "" +
+		" Item     │ Qty │ Price │ Note \n" +
+		"──────────┼─────┼───────┼──────\n" +
+		" pen      │   2 │   1.5 │ blue \n" +
+		" notebook │  12 │    10 │  a5  \n"
+	if str := Format(rows, compact, WithLayout("l r r c")); str != expected {
+		t.Errorf("layout:\n%q", str)
+	}
+
+	//bars are skipped, missing columns keep theme's alignment
+	expected = "" +
+		"   Item   │ Qty │ Price │ Note \n" +
+		"──────────┼─────┼───────┼──────\n" +
+		"   pen    │   2 │ 1.5   │ blue \n" +
+		" notebook │  12 │ 10    │ a5   \n"
+	if str := Format(rows, compact, WithLayout("|c|r|")); str != expected {
+		t.Errorf("latex:\n%q", str)
+	}
+
+	//unknown letters are errors
+	if _, err := FormatContext(context.Background(), rows, WithLayout("l.r")); err == nil || !strings.Contains(err.Error(), `unknown letter '.'`) {
+		t.Errorf("unknown letter: %v", err)
+	}
+
+	//headless grid
+	if str := Format("_ _\n1 a\n22 b", WithTheme(ThemePlain), WithLayout("rl")); str != "  1  a \n 22  b \n" {
+		t.Errorf("headless:\n%q", str)
+	}
+
+	//row numbers of struct lists are not counted
+	type User struct {
+		Name string
+		Age  int
+	}
+	users := []User{{"alice", 30}, {"bob", 4}}
+	if str := Format(users, WithTheme(ThemePlain), WithLayout("l r")); str != "    Name   Age \n 1  alice   30 \n 2  bob      4 \n" {
+		t.Errorf("struct list:\n%q", str)
+	}
+}
+
+func TestSchemaValidation(t *testing.T) {
+	schema := WithSchema(Schema{{Name: "Item"}, {Name: "Price", Type: TypeFloat}, {Name: "Paid", Type: TypeBool}})
+	plain := WithTheme(ThemePlain)
+
+	//valid rows, empty cells fit every type
+	if str := Format("Item Price Paid\npen 1.5 true\nink _ no", plain, schema); str != " Item  Price  Paid \n pen    1.5   true \n ink           no  \n" {
+		t.Errorf("valid:\n%q", str)
+	}
+
+	//cell of wrong type
+	_, err := FormatContext(context.Background(), "Item Price Paid\npen 1.5 true\nink free no", schema)
+	if e, ok := err.(*SchemaError); !ok || e.Row != 2 || e.Column != "Price" || e.Reason != `"free" is not float` {
+		t.Errorf("type: %v", err)
+	}
+
+	//row of wrong arity
+	_, err = FormatContext(context.Background(), "Item Price Paid\npen 1.5", schema)
+	if e, ok := err.(*SchemaError); !ok || e.Row != 1 || e.Column != "" || err.Error() != "table: row 1: 2 cells, header has 3" {
+		t.Errorf("arity: %v", err)
+	}
+
+	//invalid cells highlighted
+	expected := " Item  Price  Paid  \n pen   \x1b[41mfree\x1b[0m   \x1b[41mmaybe\x1b[0m \n"
+	if str := Format("Item Price Paid\npen free maybe", plain, schema, WithInvalidStyle("41")); str != expected {
+		t.Errorf("highlight:\n%q", str)
+	}
+}
@@ -0,0 +1,85 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+//reflect.Type -> func(v interface{}) string
+var converters sync.Map
+
+//convert values of type t everywhere, pointers to t are converted too,
+//a struct field with type tag of Convertable still uses Convert,
+//nil f removes the converter
+func RegisterConverter(t reflect.Type, f func(v interface{}) string) {
+	if f == nil {
+		converters.Delete(t)
+		return
+	}
+	converters.Store(t, f)
+}
+
+//convert struct fields of the named column for one call, such as fields of types the caller does not own,
+//type tags and registered converters are overridden, options of several names are merged
+func WithConverter(name string, f func(v interface{}) string) Option {
+	return func(this *Options) {
+		all := make(map[string]func(v interface{}) string, len(this.Converters)+1)
+		for k, v := range this.Converters {
+			all[k] = v
+		}
+		all[name] = f
+		this.Converters = all
+	}
+}
+
+//registered converter of type
+func converterOf(t reflect.Type) (f func(v interface{}) string, ok bool) {
+	c, ok := converters.Load(t)
+	if !ok {
+		return nil, false
+	}
+	return c.(func(v interface{}) string), true
+}
+
+//convert non-nil value with registered converter, pointers are dereferenced
+func convertValue(v reflect.Value) (str string, ok bool) {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if f, ok := converterOf(v.Type()); ok {
+			return f(v.Interface()), true
+		}
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if f, ok := converterOf(v.Type()); ok {
+		return f(v.Interface()), true
+	}
+	return "", false
+}
+
+//error of ConvertableV2
+type ConvertError struct {
+	Field string
+	//data row from 1
+	Row int
+	Err error
+}
+
+func (this *ConvertError) Error() string {
+	return fmt.Sprintf("table: convert %s of row %d: %v", this.Field, this.Row, this.Err)
+}
+
+func (this *ConvertError) Unwrap() error {
+	return this.Err
+}
+
+//convert field of struct row, an error aborts formatting
+func (this *state) convertV2(obj ConvertableV2, field fieldInfo, value reflect.Value) string {
+	str, err := obj.Convert(Conversion{Field: field.name, Type: field.typeTag, Index: this.structRow, Value: value.Interface(), Row: obj})
+	if err != nil {
+		panic(abort{&ConvertError{Field: field.name, Row: this.structRow + 1, Err: err}})
+	}
+	return str
+}
@@ -0,0 +1,108 @@
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type level int
+
+//registered converters apply to fields, lists and map values
+func TestRegisterConverter(t *testing.T) {
+	RegisterConverter(reflect.TypeOf(time.Time{}), func(v interface{}) string {
+		return v.(time.Time).Format("2006-01-02")
+	})
+	RegisterConverter(reflect.TypeOf(level(0)), func(v interface{}) string {
+		return [...]string{"low", "high"}[v.(level)]
+	})
+	defer RegisterConverter(reflect.TypeOf(time.Time{}), nil)
+	defer RegisterConverter(reflect.TypeOf(level(0)), nil)
+
+	day := time.Date(2020, 5, 17, 10, 0, 0, 0, time.UTC)
+	type Task struct {
+		Name  string
+		Due   *time.Time
+		Level level
+	}
+	str := Format([]Task{{"a", &day, 1}, {"b", nil, 0}})
+	for _, s := range []string{"2020-05-17", "high", "low"} {
+		if !strings.Contains(str, s) {
+			t.Errorf("%s expected:\n%s", s, str)
+		}
+	}
+
+	//registered struct type is a single cell
+	str = Format([]time.Time{day}, func(o *Options) { o.UseBoard = false })
+	if str != " 1  2020-05-17 \n" {
+		t.Errorf("list of time:\n%q", str)
+	}
+
+	//nested nil pointers are empty
+	n := 1
+	p := &n
+	type Ref struct {
+		P **int
+	}
+	if str := Format([]Ref{{&p}, {new(*int)}, {nil}}, WithTheme(ThemePlain), func(o *Options) { o.Deterministic = true }); str != "    P \n 1  1 \n 2    \n 3    \n" {
+		t.Errorf("nil pointers:\n%q", str)
+	}
+
+	//removed converter
+	RegisterConverter(reflect.TypeOf(level(0)), nil)
+	if str := Format(map[string]level{"x": 1}); strings.Contains(str, "high") {
+		t.Errorf("converter not removed:\n%s", str)
+	}
+}
+
+type price struct {
+	Amount   float64 `table:",money"`
+	Currency string  `table:",,nolist"`
+}
+
+func (this price) Convert(c Conversion) (string, error) {
+	if this.Currency == "" {
+		return "", errors.New("no currency")
+	}
+	return fmt.Sprintf("%d:%.2f %s", c.Index, c.Value, c.Row.(price).Currency), nil
+}
+
+//ConvertableV2 knows the row and can fail
+func TestConvertableV2(t *testing.T) {
+	prices := []price{{1.5, "USD"}, {2, "EUR"}}
+	if str := Format(prices, WithTheme(ThemePlain)); str != "      Amount   \n 1  0:1.50 USD \n 2  1:2.00 EUR \n" {
+		t.Errorf("converted:\n%q", str)
+	}
+
+	prices[1].Currency = ""
+	_, err := FormatContext(context.Background(), prices)
+	if e, ok := err.(*ConvertError); !ok || e.Field != "Amount" || e.Row != 2 || e.Err.Error() != "no currency" {
+		t.Errorf("error: %v", err)
+	}
+}
+
+//per-call converters by column name override registered converters
+func TestWithConverter(t *testing.T) {
+	RegisterConverter(reflect.TypeOf(level(0)), func(v interface{}) string {
+		return "registered"
+	})
+	defer RegisterConverter(reflect.TypeOf(level(0)), nil)
+
+	type Task struct {
+		Due   time.Time
+		Level level
+	}
+	day := time.Date(2020, 5, 17, 10, 0, 0, 0, time.UTC)
+	str := Format([]Task{{day, 1}}, WithTheme(ThemePlain), WithConverter("Due", func(v interface{}) string {
+		return v.(time.Time).Format("01/02")
+	}), WithConverter("Level", func(v interface{}) string {
+		return strings.Repeat("*", int(v.(level))+1)
+	}))
+	if str != "     Due   Level \n 1  05/17   **   \n" {
+		t.Errorf("converted:\n%q", str)
+	}
+}
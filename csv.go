@@ -0,0 +1,59 @@
+package table
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//predefined renderers of records, header and data rows without units
+var (
+	//comma separated values
+	CSVRenderer Renderer = csvRenderer(',')
+
+	//tab separated values
+	TSVRenderer Renderer = csvRenderer('\t')
+)
+
+//records separated by comma
+func csvRenderer(comma rune) Renderer {
+	return RendererFunc(func(t *Table, w io.Writer) error {
+		cw := csv.NewWriter(w)
+		cw.Comma = comma
+		cw.UseCRLF = t.options.LineEnding == "\r\n"
+		escape := t.options.EscapeFormulas
+		if t.Headers != nil {
+			cw.Write(escapeFormulas(t.Headers, escape))
+		}
+		for _, row := range t.Rows {
+			cw.Write(escapeFormulas(row, escape))
+		}
+		cw.Flush()
+		return cw.Error()
+	})
+}
+
+//copy of cells with formulas prefixed by apostrophe, see owasp.org/www-community/attacks/CSV_Injection
+func escapeFormulas(cells []string, escape bool) []string {
+	if !escape {
+		return cells
+	}
+	escaped := make([]string, len(cells))
+	for i, val := range cells {
+		if isFormula(val) {
+			val = "'" + val
+		}
+		escaped[i] = val
+	}
+	return escaped
+}
+
+//cell starting with = + - @ tab or carriage return, numbers such as -1.5 are not formulas
+func isFormula(val string) bool {
+	if val == "" || !strings.ContainsRune("=+-@\t\r", rune(val[0])) {
+		return false
+	}
+	_, err := strconv.ParseFloat(val, 64)
+	return err != nil
+}
@@ -0,0 +1,127 @@
+package table
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+//names of summary statistics
+var statNames = []string{"count", "min", "max", "mean", "median"}
+
+//summary statistics of numeric columns of struct slice, or of numeric slice as column "value",
+//nil values are not counted
+func Describe(list interface{}, opts ...Option) string {
+	return NewFormatter(opts...).Describe(list)
+}
+
+//summary statistics with the formatter's options
+func (this *Formatter) Describe(list interface{}, opts ...Option) string {
+	s := newState(this.options, opts)
+	names, columns := describeColumns(reflect.ValueOf(list))
+
+	stats := make([][]string, len(columns))
+	for col, nums := range columns {
+		stats[col] = s.describe(nums)
+	}
+
+	s.addRow(concat(s.emptyHeader(1), names))
+	for i, stat := range statNames {
+		row := []string{stat}
+		for col := range columns {
+			row = append(row, stats[col][i])
+		}
+		s.addRow(row)
+	}
+	return s.format()
+}
+
+//numeric columns of list
+func describeColumns(v reflect.Value) (names []string, columns [][]float64) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, nil
+	}
+
+	t := v.Type().Elem()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	//numeric slice
+	if t.Kind() != reflect.Struct {
+		nums := []float64{}
+		for i := 0; i < v.Len(); i++ {
+			if n := toFloat(v.Index(i)); !math.IsNaN(n) {
+				nums = append(nums, n)
+			}
+		}
+		return []string{"value"}, [][]float64{nums}
+	}
+
+	//numeric listed fields of struct
+	var fields []int
+	for _, field := range getStructInfo(t).fields {
+		if field.list && isNumber(t.Field(field.index).Type) {
+			names = append(names, field.name)
+			fields = append(fields, field.index)
+			columns = append(columns, []float64{})
+		}
+	}
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		for elem.Kind() == reflect.Ptr && !elem.IsNil() {
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct {
+			continue
+		}
+		for col, index := range fields {
+			if n := toFloat(elem.Field(index)); !math.IsNaN(n) {
+				columns[col] = append(columns[col], n)
+			}
+		}
+	}
+	return names, columns
+}
+
+//int, uint or float type, pointers are dereferenced
+func isNumber(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+//count, min, max, mean and median of nums, statistics of no number are placeholder
+func (this *state) describe(nums []float64) []string {
+	stats := []string{strconv.Itoa(len(nums)), this.Placeholder, this.Placeholder, this.Placeholder, this.Placeholder}
+	if len(nums) == 0 {
+		return stats
+	}
+
+	sorted := append([]float64{}, nums...)
+	sort.Float64s(sorted)
+	sum := 0.0
+	for _, n := range sorted {
+		sum += n
+	}
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + median) / 2
+	}
+
+	for i, n := range []float64{sorted[0], sorted[len(sorted)-1], sum / float64(len(sorted)), median} {
+		stats[i+1] = strconv.FormatFloat(n, 'g', 6, 64)
+	}
+	return stats
+}
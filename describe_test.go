@@ -0,0 +1,39 @@
+package table
+
+import (
+	"testing"
+)
+
+//summary statistics of struct slice and numeric slice
+func TestDescribe(t *testing.T) {
+	type Sample struct {
+		Name  string
+		Score int
+		Cost  *float64
+	}
+	cost := 1.5
+	samples := []Sample{{"a", 1, &cost}, {"b", 2, nil}, {"c", 4, &cost}, {"d", 10, nil}}
+	plain := func(o *Options) { o.UseBoard = false }
+
+	expected := "" +
+		"         Score  Cost \n" +
+		" count     4     2   \n" +
+		"  min      1    1.5  \n" +
+		"  max     10    1.5  \n" +
+		"  mean   4.25   1.5  \n" +
+		" median    3    1.5  \n"
+	if str := Describe(samples, plain); str != expected {
+		t.Errorf("struct:\n%q", str)
+	}
+
+	expected = "" +
+		"         value \n" +
+		" count     0   \n" +
+		"  min          \n" +
+		"  max          \n" +
+		"  mean         \n" +
+		" median        \n"
+	if str := Describe([]float64{}, plain); str != expected {
+		t.Errorf("empty:\n%q", str)
+	}
+}
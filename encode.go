@@ -0,0 +1,715 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//encode object into the grid, ignore panics, the first row is header
+func (this *state) encode(obj interface{}) {
+	//ignore all the panic
+	defer func() {
+		if r := recover(); r != nil {
+			//cancellation and errors are returned by FormatContext
+			a, ok := r.(abort)
+			if ok && this.ctx != nil {
+				panic(r)
+			} else if ok {
+				r = a.err
+			}
+			this.showError(r)
+		}
+	}()
+
+	v := reflect.ValueOf(obj)
+
+	this.encodeAny(v)
+}
+
+//replace the grid with a single cell of error
+func (this *state) showError(err interface{}) {
+	this.reset()
+	this.addRow(this.emptyHeader(1))
+	this.addRow([]string{fmt.Sprint(err)})
+}
+
+//encode any type
+func (this *state) encodeAny(v reflect.Value) {
+	//values shown as a single cell
+	if v.IsValid() && isLeaf(v.Type()) {
+		this.addRow([]string{this.formatValue(v)})
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		this.encodeAny(v.Elem())
+	case reflect.String:
+		this.encodeString(v)
+	case reflect.Array, reflect.Slice:
+		this.encodeList(v)
+	case reflect.Struct:
+		this.encodeStruct(v)
+	case reflect.Map:
+		this.encodeMap(v)
+	case reflect.Func:
+		this.encodeFunc(v)
+	default:
+		_, vals := this.encodePlain(v)
+		this.addRow(vals)
+	}
+}
+
+//string type, classic format type
+func (this *state) encodeString(v reflect.Value) {
+	if v.Kind() != reflect.String {
+		return
+	}
+
+	obj := v.Interface()
+
+	//raw string, do not tokenize
+	if o, ok := obj.(RawString); ok {
+		this.addRow(this.emptyHeader(1))
+		this.addRow([]string{string(o)})
+		return
+	}
+
+	//normal string
+	this.parse(v.String())
+}
+
+//parse string to rows by its format, the only place where separators are used
+func (this *state) parse(data string) {
+	if this.parseInput(data) {
+		return
+	}
+	lines := this.getLines(data)
+	this.grow(len(lines))
+	for _, line := range lines {
+		this.addRow(this.getFields(line))
+	}
+}
+
+//function type, get the function name
+func (this *state) encodePlainFunc(v reflect.Value) (str string) {
+	if v.Kind() != reflect.Func {
+		return str
+	}
+
+	return runtime.FuncForPC(v.Pointer()).Name()
+}
+
+//function type, get the function name
+func (this *state) encodeFunc(v reflect.Value) {
+	if v.Kind() != reflect.Func {
+		return
+	}
+
+	this.addRow(this.emptyHeader(1))
+	this.addRow([]string{this.encodePlainFunc(v)})
+}
+
+//base types, return header cells and value cells
+func (this *state) encodePlain(v reflect.Value) (keys, vals []string) {
+	if isNil(v) {
+		return this.emptyHeader(1), []string{this.nilText()}
+	}
+
+	//values shown as a single cell
+	if isLeaf(v.Type()) {
+		return this.emptyHeader(1), []string{this.formatValue(v)}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		keys, vals = this.encodePlain(v.Elem())
+	case reflect.Struct:
+		keys, vals = this.encodePlainStruct(v)
+	case reflect.Func:
+		keys, vals = this.emptyHeader(1), []string{this.encodePlainFunc(v)}
+	default:
+		keys, vals = this.emptyHeader(1), []string{this.formatValue(v)}
+	}
+
+	return keys, vals
+}
+
+//text of a single value
+func (this *state) formatValue(v reflect.Value) string {
+	if isNil(v) {
+		return this.nilText()
+	}
+
+	//registered converter
+	if str, ok := convertValue(v); ok {
+		return str
+	}
+	if str, ok := this.formatBig(v); ok {
+		return str
+	}
+	if str, ok := this.formatNet(v); ok {
+		return str
+	}
+	if str, ok := this.formatBinary(v); ok {
+		return str
+	}
+	if v.Kind() == reflect.Bool {
+		if str, ok := this.BoolText.text(v.Bool()); ok {
+			return str
+		}
+	}
+
+	obj := v.Interface()
+	switch o := obj.(type) {
+	case Link:
+		return this.link(o.Text, o.URL)
+	case *Link:
+		return this.link(o.Text, o.URL)
+	}
+	if this.Deterministic {
+		if str, ok := this.hideAddress(v); ok {
+			return str
+		}
+	}
+	return fmt.Sprint(obj)
+}
+
+//value instead of address which differs between runs, pointers to structs, lists and maps are shown as &{...} anyway,
+//channels and functions are shown as their types
+func (this *state) hideAddress(v reflect.Value) (str string, ok bool) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		switch v.Elem().Kind() {
+		case reflect.Struct, reflect.Array, reflect.Slice, reflect.Map:
+			return "", false
+		}
+		return this.formatValue(v.Elem()), true
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return v.Type().String(), true
+	}
+	return "", false
+}
+
+//keys of map sorted by their cells and Go syntax, rows are in the same order on every run like columns
+func (this *state) mapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	texts := make([]string, len(keys))
+	for i, key := range keys {
+		texts[i] = this.encodeCell(key) + "\x00" + fmt.Sprintf("%#v", key.Interface())
+	}
+	sort.Sort(byTitle{texts, keys, this.compare})
+	return keys
+}
+
+//types shown as a single cell although they are structs, lists or maps
+func isLeaf(t reflect.Type) bool {
+	for {
+		if _, ok := converterOf(t); ok {
+			return true
+		}
+		if t.Kind() != reflect.Ptr {
+			break
+		}
+		t = t.Elem()
+	}
+	return t == linkType || isBig(t) || isNet(t)
+}
+
+//single cell of base types
+func (this *state) encodeCell(v reflect.Value) string {
+	_, vals := this.encodePlain(v)
+	return strings.Join(vals, this.OverFlowSeparator)
+}
+
+//nil pointer, interface, map, slice or invalid value
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Interface:
+		return v.IsNil() || isNil(v.Elem())
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return v.IsNil()
+	}
+	return false
+}
+
+//text of nil value
+func (this *state) nilText() string {
+	if this.NilText != "" {
+		return this.NilText
+	}
+	return this.Placeholder
+}
+
+//map type
+func (this *state) encodeMap(v reflect.Value) {
+	if v.Kind() != reflect.Map {
+		return
+	}
+
+	//map of struct
+	if this.encodeStructMap(v) {
+		return
+	}
+
+	//map of map
+	if this.encodeMatrix(v) {
+		return
+	}
+
+	//map of list
+	if this.encodeSliceMap(v) {
+		return
+	}
+
+	keys := this.mapKeys(v)
+	for i, key := range keys {
+		value := v.MapIndex(key)
+
+		k1, v1 := this.encodePlain(key)
+		k2, v2 := this.encodePlain(value)
+
+		if i == 0 {
+			this.addRow(concat(k1, k2))
+		}
+		this.addRow(concat(v1, v2))
+	}
+}
+
+//array, slice type
+func (this *state) encodeList(v reflect.Value) {
+	if v.Kind() != reflect.Array && v.Kind() != reflect.Slice {
+		return
+	}
+
+	//list of struct
+	if this.encodeStructList(v) {
+		return
+	}
+
+	//list of map
+	if this.encodeMapList(v) {
+		return
+	}
+
+	//format list
+	this.grow(v.Len() + 1)
+	for i := 0; i < v.Len(); i++ {
+		keys, vals := this.encodePlain(v.Index(i))
+
+		if i == 0 {
+			this.indexed = true
+			this.addRow(concat(this.emptyHeader(1), keys))
+		}
+		this.addRow(concat([]string{strconv.Itoa(i + 1)}, vals))
+	}
+}
+
+//struct list, one row per element and one column per listed field
+func (this *state) encodeStructList(v reflect.Value) (ok bool) {
+	keys, ok := this.structKeys(v.Type().Elem())
+	if !ok {
+		return false
+	}
+
+	raw := concatRaw(1, this.structRaw(v.Type().Elem()))
+	this.grow(v.Len() + 1)
+	this.indexed = true
+	this.addRow(concat(this.emptyHeader(1), keys))
+	for i := 0; i < v.Len(); i++ {
+		vals := this.structVals(v.Index(i), len(keys))
+		this.addCells(concat([]string{strconv.Itoa(i + 1)}, vals), raw)
+	}
+
+	return true
+}
+
+//struct map, one row per entry, key followed by one column per listed field
+func (this *state) encodeStructMap(v reflect.Value) (ok bool) {
+	keys, ok := this.structKeys(v.Type().Elem())
+	if !ok {
+		return false
+	}
+
+	structRaw := this.structRaw(v.Type().Elem())
+	for i, key := range this.mapKeys(v) {
+		k, kvals := this.encodePlain(key)
+		if i == 0 {
+			this.addRow(concat(k, keys))
+		}
+
+		vals := this.structVals(v.MapIndex(key), len(keys))
+		this.addCells(concat(kvals, vals), concatRaw(len(kvals), structRaw))
+	}
+
+	return true
+}
+
+//slice map, one section per entry, key is shown on the first row of the section
+func (this *state) encodeSliceMap(v reflect.Value) (ok bool) {
+	t := v.Type().Elem()
+	if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+		return false
+	}
+	keys, isStruct := this.structKeys(t.Elem())
+	if !isStruct {
+		keys = this.emptyHeader(1)
+	}
+	raw := concatRaw(2, this.structRaw(t.Elem()))
+
+	this.addRow(concat(this.emptyHeader(2), keys))
+	for _, key := range this.mapKeys(v) {
+		kstr := this.encodeCell(key)
+
+		list := v.MapIndex(key)
+		if list.Len() == 0 {
+			this.addRow([]string{kstr, this.Placeholder, this.nilText()})
+			continue
+		}
+
+		for j := 0; j < list.Len(); j++ {
+			var vals []string
+			if isStruct {
+				vals = this.structVals(list.Index(j), len(keys))
+			} else {
+				vals = []string{this.encodeCell(list.Index(j))}
+			}
+
+			//key only once per section
+			if j != 0 {
+				kstr = this.Placeholder
+			}
+			this.addCells(concat([]string{kstr, strconv.Itoa(j + 1)}, vals), raw)
+		}
+	}
+
+	return true
+}
+
+//map list, one row per map, union of keys as columns
+func (this *state) encodeMapList(v reflect.Value) (ok bool) {
+	t := v.Type().Elem()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Map {
+		return false
+	}
+
+	//encode rows and collect keys
+	cells := make([]map[string]string, v.Len())
+	union := map[string]bool{}
+	for i := range cells {
+		cells[i] = map[string]string{}
+		m := v.Index(i)
+		for m.Kind() == reflect.Ptr && !m.IsNil() {
+			m = m.Elem()
+		}
+		if isNil(m) {
+			continue
+		}
+		for _, key := range m.MapKeys() {
+			k := this.encodeCell(key)
+			cells[i][k] = this.encodeCell(m.MapIndex(key))
+			union[k] = true
+		}
+	}
+
+	keys := sortedKeys(union)
+
+	this.indexed = true
+	this.addRow(concat(this.emptyHeader(1), keys))
+	for i, row := range cells {
+		vals := []string{strconv.Itoa(i + 1)}
+		for _, k := range keys {
+			val, ok := row[k]
+			if !ok {
+				val = this.Placeholder
+			}
+			vals = append(vals, val)
+		}
+		this.addRow(vals)
+	}
+
+	return true
+}
+
+//nested map, outer keys as row labels, union of inner keys as columns
+func (this *state) encodeMatrix(v reflect.Value) (ok bool) {
+	t := v.Type().Elem()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Map {
+		return false
+	}
+
+	//encode cells and collect inner keys
+	outer := this.mapKeys(v)
+	labels := make([]string, len(outer))
+	cells := make([]map[string]string, len(outer))
+	union := map[string]bool{}
+	for i, key := range outer {
+		labels[i] = this.encodeCell(key)
+		cells[i] = map[string]string{}
+
+		m := v.MapIndex(key)
+		for (m.Kind() == reflect.Ptr || m.Kind() == reflect.Interface) && !m.IsNil() {
+			m = m.Elem()
+		}
+		if isNil(m) {
+			continue
+		}
+		for _, inner := range m.MapKeys() {
+			k := this.encodeCell(inner)
+			cells[i][k] = this.encodeCell(m.MapIndex(inner))
+			union[k] = true
+		}
+	}
+
+	cols := sortedKeys(union)
+
+	this.addRow(concat(this.emptyHeader(1), cols))
+	for i, label := range labels {
+		vals := []string{label}
+		for _, k := range cols {
+			val, ok := cells[i][k]
+			if !ok {
+				val = this.Placeholder
+			}
+			vals = append(vals, val)
+		}
+		this.addRow(vals)
+	}
+
+	return true
+}
+
+//sorted keys of set
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+//listed field names of struct type, pointers are dereferenced
+func (this *state) structKeys(t reflect.Type) (keys []string, ok bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || isLeaf(t) {
+		return nil, false
+	}
+
+	info := getStructInfo(t)
+	keys = info.absKeys
+	if info.params != nil {
+		this.tagParams = info.params
+	}
+	if len(this.Computed) != 0 {
+		keys = concat(keys, this.computedKeys())
+	}
+	return keys, len(keys) != 0
+}
+
+//raw flags of listed fields of struct type, nil for other types
+func (this *state) structRaw(t reflect.Type) []bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || isLeaf(t) {
+		return nil
+	}
+	return getStructInfo(t).absRaw
+}
+
+//raw flags of a row, offset leading cells are not raw
+func concatRaw(offset int, raw []bool) []bool {
+	if raw == nil {
+		return nil
+	}
+	return append(make([]bool, offset, offset+len(raw)), raw...)
+}
+
+//listed field values and computed values of struct, nil struct pointer is filled with nil text
+func (this *state) structVals(v reflect.Value, num int) (vals []string) {
+	elem := v
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if isNil(v) {
+		vals = make([]string, num)
+		for i := range vals {
+			vals[i] = this.nilText()
+		}
+		return vals
+	}
+
+	_, _, _, vals = this.processStruct(v)
+	if len(this.Computed) != 0 {
+		vals = concat(vals, this.computedVals(elem.Interface()))
+	}
+	this.structRow++
+	return vals
+}
+
+//return key cells and value cells
+func (this *state) encodePlainStruct(v reflect.Value) (keys, vals []string) {
+	_, _, keys, vals = this.processStruct(v)
+
+	if len(keys) == 0 {
+		keys = this.emptyHeader(1)
+		vals = []string{fmt.Sprint(v.Interface())}
+	}
+
+	return keys, vals
+}
+
+//struct type
+func (this *state) encodeStruct(v reflect.Value) {
+	keys, vals, _, _ := this.processStruct(v)
+	if len(keys) == 0 {
+		this.addRow([]string{fmt.Sprint(v.Interface())})
+		return
+	}
+
+	raw := getStructInfo(v.Type()).detRaw
+	this.addRow(this.emptyHeader(2))
+	for i := 0; i < len(keys); i++ {
+		if !this.isSelected(keys[i]) {
+			continue
+		}
+		this.addCells([]string{this.headerName(keys[i]), vals[i]}, []bool{false, raw[i]})
+	}
+}
+
+//process struct, return objfmt fields and listfmt fields
+func (this *state) processStruct(v reflect.Value) (detKeys, detVals, absKeys, absVals []string) {
+	if v.Kind() != reflect.Struct {
+		return []string{}, []string{}, []string{}, []string{}
+	}
+
+	info := getStructInfo(v.Type())
+	detKeys = info.detKeys
+	absKeys = info.absKeys
+	detVals = make([]string, 0, len(info.fields))
+	absVals = make([]string, 0, len(absKeys))
+
+	//type tag needs the whole object
+	var obj Convertable
+	var objV2 ConvertableV2
+	if info.convertable {
+		obj = v.Interface().(Convertable)
+	} else if info.convertableV2 {
+		objV2 = v.Interface().(ConvertableV2)
+	}
+
+	//struct fields
+	for _, field := range info.fields {
+		value := v.Field(field.index)
+		if field.method != "" {
+			value = callMethod(v, field)
+		}
+
+		var valStr string
+		if f, ok := this.Converters[field.name]; ok {
+			valStr = f(value.Interface())
+		} else if obj != nil && field.typeTag != "" {
+			valStr = obj.Convert(value.Interface(), field.typeTag)
+		} else if objV2 != nil && field.typeTag != "" {
+			valStr = this.convertV2(objV2, field, value)
+		} else if field.spark {
+			valStr = Sparkline(value.Interface())
+		} else if str, ok := this.formatFloat(value, this.precision(field), this.notation(field)); ok {
+			valStr = str
+		} else {
+			valStr = this.formatValue(value)
+		}
+
+		//json tag, invalid json is shown as is
+		if field.json {
+			if str, ok := formatJSON(value, field.jsonIndent); ok {
+				valStr = str
+			}
+		}
+
+		//link tag, the value is the url
+		if field.link && !isNil(value) {
+			valStr = this.link(valStr, fmt.Sprint(value.Interface()))
+		}
+
+		//list tag
+		detVals = append(detVals, valStr)
+		if field.list {
+			absVals = append(absVals, valStr)
+		}
+	}
+	return detKeys, detVals, absKeys, absVals
+}
+
+//call the method of field on struct, the result is the value
+func callMethod(v reflect.Value, field fieldInfo) reflect.Value {
+	if field.ptrMethod {
+		if !v.CanAddr() {
+			p := reflect.New(v.Type())
+			p.Elem().Set(v)
+			v = p.Elem()
+		}
+		v = v.Addr()
+	}
+	return v.MethodByName(field.method).Call(nil)[0]
+}
+
+//header of placeholders
+func (this *state) emptyHeader(colNum int) []string {
+	fields := make([]string, colNum)
+	for i := range fields {
+		fields[i] = this.Placeholder
+	}
+	return fields
+}
+
+//join cells of two parts into one row
+func concat(a, b []string) []string {
+	row := make([]string, 0, len(a)+len(b))
+	row = append(row, a...)
+	return append(row, b...)
+}
+
+//header cells, value cells and raw flags of a row of stream
+func (this *state) rowCells(v reflect.Value) (keys, vals []string, raw []bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			break
+		}
+		v = v.Elem()
+	}
+
+	if v.IsValid() && !isLeaf(v.Type()) {
+		switch v.Kind() {
+		case reflect.Struct:
+			if keys, ok := this.structKeys(v.Type()); ok {
+				return keys, this.structVals(v, len(keys)), this.structRaw(v.Type())
+			}
+		case reflect.Slice, reflect.Array:
+			vals = make([]string, v.Len())
+			for i := range vals {
+				vals[i] = this.encodeCell(v.Index(i))
+			}
+			return this.emptyHeader(len(vals)), vals, nil
+		}
+	}
+
+	keys, vals = this.encodePlain(v)
+	return keys, vals, nil
+}
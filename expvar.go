@@ -0,0 +1,63 @@
+package table
+
+import (
+	"bytes"
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"sort"
+)
+
+//expvar variables by name, such as cmdline and memstats, json objects are flattened one level
+//with keys such as memstats.HeapAlloc, deeper values are compact json
+func Vars() map[string]string {
+	vars := map[string]string{}
+	expvar.Do(func(kv expvar.KeyValue) {
+		raw := json.RawMessage(kv.Value.String())
+		var fields map[string]json.RawMessage
+		if len(raw) == 0 || raw[0] != '{' || json.Unmarshal(raw, &fields) != nil {
+			vars[kv.Key] = jsonText(raw)
+			return
+		}
+		for name, field := range fields {
+			vars[kv.Key+"."+name] = jsonText(field)
+		}
+	})
+	return vars
+}
+
+//handler of a table of Vars sorted by name, such as a debug page beside /debug/vars,
+//formats are decided by Accept header like Handler
+func ExpvarHandler(opts ...Option) http.Handler {
+	return Handler(func(r *http.Request) interface{} { return sortedVars() }, opts...)
+}
+
+//row of ExpvarHandler
+type expvarRow struct {
+	Name  string
+	Value string
+}
+
+//Vars as rows sorted by name
+func sortedVars() []expvarRow {
+	vars := Vars()
+	rows := make([]expvarRow, 0, len(vars))
+	for name, value := range vars {
+		rows = append(rows, expvarRow{name, value})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Name < rows[j].Name })
+	return rows
+}
+
+//text of json value, strings are unquoted and others are compact
+func jsonText(raw json.RawMessage) string {
+	var str string
+	if len(raw) != 0 && raw[0] == '"' && json.Unmarshal(raw, &str) == nil {
+		return str
+	}
+	var buf bytes.Buffer
+	if json.Compact(&buf, raw) != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
@@ -0,0 +1,31 @@
+package table
+
+import (
+	"expvar"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+//expvar variables as key and value rows
+func TestExpvar(t *testing.T) {
+	m := expvar.NewMap("tabletest.requests")
+	m.Add("ok", 3)
+	m.Add("failed", 1)
+	expvar.NewString("tabletest.version").Set("1.2")
+
+	vars := Vars()
+	if vars["tabletest.requests.ok"] != "3" || vars["tabletest.requests.failed"] != "1" || vars["tabletest.version"] != "1.2" || vars["memstats.NumGC"] == "" {
+		t.Errorf("vars: %v", vars)
+	}
+
+	r := httptest.NewRequest("GET", "/debug/table", nil)
+	r.Header.Set("Accept", "text/plain")
+	w := httptest.NewRecorder()
+	ExpvarHandler(WithTheme(ThemePlain)).ServeHTTP(w, r)
+	body := w.Body.String()
+	text := strings.Join(strings.Fields(body), " ")
+	if !strings.HasPrefix(text, "Name Value ") || !strings.Contains(text, " tabletest.requests.failed 1 ") || !strings.Contains(text, " tabletest.requests.ok 3 ") || strings.Index(body, "tabletest.requests.failed") > strings.Index(body, "tabletest.requests.ok") {
+		t.Errorf("handler:\n%s", body)
+	}
+}
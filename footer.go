@@ -0,0 +1,144 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//footer functions of footer tags
+var footers = map[string]func(vals []string) string{
+	"sum":   sumFooter,
+	"avg":   avgFooter,
+	"count": countFooter,
+	"last":  lastFooter,
+}
+
+//add footer row of footer tags under data rows, the table without data rows has no footer
+func (this *state) addFooter() {
+	head := this.headRows()
+	if len(this.cells) <= head {
+		return
+	}
+
+	line := make([]string, this.colNum)
+	found := false
+	for col := range line {
+		name := this.footerName(col)
+		foot, ok := footers[name]
+		if !ok {
+			continue
+		}
+
+		//non-empty data cells
+		var vals []string
+		for _, row := range this.cells[head:] {
+			if val := row[col]; val != "" && val != this.emptyText(col, "") {
+				vals = append(vals, val)
+			}
+		}
+		//totals are formatted like the cells
+		val := foot(vals)
+		if name == "sum" || name == "avg" {
+			val = this.formatCell(col, val)
+		}
+		line[col] = this.accounting(val)
+		found = true
+	}
+	if !found {
+		return
+	}
+
+	for col, val := range line {
+		if size := this.cellWidth(val); size > this.widths[col] {
+			this.widths[col] = size
+		}
+	}
+	this.cells = append(this.cells, line)
+	this.footer = true
+}
+
+//footer function of column, Schema overrides footer tags
+func (this *state) footerName(col int) string {
+	if col < len(this.footers) && this.footers[col] != "" {
+		return this.footers[col]
+	}
+	if col < len(this.names) {
+		return this.tagParams["footer"][this.names[col]]
+	}
+	return ""
+}
+
+//rows of footer, 0 or 1
+func (this *state) footRows() int {
+	if this.footer {
+		return 1
+	}
+	return 0
+}
+
+//number of cell such as 1,234.5 and (12.50) of Accounting, with digits after the point
+func parseNumber(val string) (n float64, digits int, ok bool) {
+	val = strings.Replace(strings.TrimSpace(val), ",", "", -1)
+	if len(val) > 2 && val[0] == '(' && val[len(val)-1] == ')' {
+		val = "-" + val[1:len(val)-1]
+	}
+	n, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if i := strings.IndexByte(val, '.'); i >= 0 && !strings.ContainsAny(val, "eE") {
+		digits = len(val) - i - 1
+	}
+	return n, digits, true
+}
+
+//character of currency or unit around number
+func isUnit(c rune) bool {
+	return !unicode.IsDigit(c) && !strings.ContainsRune("+-.()", c)
+}
+
+//sum of numbers with the most digits of them, other cells are skipped
+func sumFooter(vals []string) string {
+	sum, digits, _ := sumNumbers(vals)
+	return strconv.FormatFloat(sum, 'f', digits, 64)
+}
+
+//average of numbers with 2 more digits than them, trailing zeros are trimmed
+func avgFooter(vals []string) string {
+	sum, digits, count := sumNumbers(vals)
+	if count == 0 {
+		return ""
+	}
+	avg := strconv.FormatFloat(sum/float64(count), 'f', digits+2, 64)
+	return strings.TrimSuffix(strings.TrimRight(avg, "0"), ".")
+}
+
+//number of non-empty cells
+func countFooter(vals []string) string {
+	return strconv.Itoa(len(vals))
+}
+
+//the last non-empty cell
+func lastFooter(vals []string) string {
+	if len(vals) == 0 {
+		return ""
+	}
+	return vals[len(vals)-1]
+}
+
+//sum, the most digits after the point and count of numbers, units such as $5 and 12ms are skipped
+func sumNumbers(vals []string) (sum float64, digits, count int) {
+	for _, val := range vals {
+		n, d, ok := parseNumber(strings.TrimFunc(val, isUnit))
+		if !ok {
+			continue
+		}
+		sum += n
+		count++
+		if d > digits {
+			digits = d
+		}
+	}
+	return sum, digits, count
+}
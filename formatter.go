@@ -0,0 +1,496 @@
+package table
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"regexp"
+)
+
+//options of a format call, a copy is taken for every call
+type Options struct {
+	RowSeparator          string
+	ColumnSeparator       string
+	Placeholder           string
+	BlankFilling          string
+	BlankFillingForHeader string
+	ColOverflow           bool
+	UseBoard              bool
+	SpaceAlt              byte
+	OverFlowSeparator     string
+	CenterFilling         byte
+	IgnoreEmptyHeader     bool
+	NilText               string
+	AmbiguousWidth        int
+	TabWidth              int
+	EscapeControl         bool
+	Hyperlinks            bool
+	EmptyText             string
+	EmptyBorder           bool
+	Strict                bool
+	ColOverflowMode       OverflowMode
+	StreamSample          int
+	BigPrecision          int
+	BigGrouping           string
+	BoolText              BoolStyle
+	BinaryEncoding        BinaryMode
+	BinaryMaxBytes        int
+	MiddleEllipsis        bool
+	NoHeader              bool
+	SubTables             bool
+	NoColor               bool
+	LegacyConsole         bool
+	Rounding              RoundingMode
+	Accounting            bool
+	NegativeStyle         Style
+	Deterministic         bool
+	Sanitize              bool
+	EscapeFormulas        bool
+	LineEnding            string
+	ASCIIOnly             bool
+	Input                 InputFormat
+	NaturalSort           bool
+	SortFold              bool
+	SortLocale            string
+
+	//border, styles, alignment and padding, nil means UseBoard decides
+	Theme *Theme
+
+	//attributes of html table
+	TableAttrs Attrs
+	//attributes of html rows, row counts data rows from 0, header rows are -1
+	RowAttrs func(row int, cells []string) Attrs
+	//attributes of html cells in a column, name is the header cell
+	ColumnAttrs func(col int, name string) Attrs
+
+	//header name -> shown name, field names of a single struct are renamed too
+	HeaderNames map[string]string
+
+	//names of shown columns in header order, nil means all
+	Columns []string
+	//names of hidden columns
+	ExcludeColumns []string
+	//data rows shown at the start and the end of table, the others are replaced by a row telling their number, 0 and 0 mean all
+	HeadRows int
+	TailRows int
+	//show every nth data row or size random data rows with a row telling the sampling, HeadRows and TailRows are not applied then
+	SampleEvery int
+	SampleSize  int
+	//seed of random sample, 0 means a random seed, or a fixed one in Deterministic mode
+	SampleSeed int64
+	//names of columns sorting data rows, a name prefixed with - sorts descending, streams are not sorted
+	SortColumns []string
+
+	//columns calculated from elements of struct list or map
+	Computed []Column
+
+	//style of changed cells of FormatChanges, empty style means bold yellow
+	ChangeStyle Style
+
+	//pattern of highlighted data cells, cells are between asterisks when colors are off
+	Highlight *regexp.Regexp
+	//style of highlighted cells, empty style means reverse video
+	HighlightStyle Style
+
+	//header name -> unit or description shown under header, units of table tags are overridden
+	Units map[string]string
+
+	//names of numeric columns colored from green to red by value
+	Heatmap []string
+
+	//indexes of data rows from 0 followed by a line
+	SectionRows []int
+	//name of column starting a section when its value changes, empty value continues the section
+	SectionColumn string
+
+	//header name -> text of empty and nil cells, empty tags are overridden
+	EmptyValues map[string]string
+
+	//header name -> cell text -> label shown instead
+	Labels map[string]map[string]string
+
+	//rules of columns by header name, tags are overridden
+	Schema Schema
+	//style of data cells not fitting the types of Schema, such as "41", empty style means they abort with *SchemaError
+	InvalidStyle Style
+	//alignments of columns in order, such as "l r r c", Schema alignments are overridden, other letters are errors
+	Layout string
+
+	//header name -> converter of struct fields, type tags and registered converters are overridden
+	Converters map[string]func(v interface{}) string
+
+	//header name -> digits after the point of float fields, prec tags are overridden
+	Precisions map[string]int
+	//header name -> exponent notation of float fields, notation tags are overridden
+	Notations map[string]Notation
+
+	//header name -> rune filling the column's cells out of theme padding, pad tags are overridden
+	PadRunes map[string]rune
+
+	//widths of columns by index without padding, 0 means the content decides, width tags are overridden
+	FixedWidths []int
+	//width limit of every column without padding, 0 means no limit, width tags and FixedWidths are not overridden
+	MaxWidth int
+	//wrap cells wider than column width limits instead of cutting them
+	WrapCells bool
+
+	//output format of Format, nil means the text table of theme
+	Renderer Renderer
+
+	//writer of WithOutput, it is checked after all the options are applied
+	output *output
+}
+
+//option modifies the options of one call or of a formatter
+type Option func(*Options)
+
+//snapshot of the global options
+func Defaults() Options {
+	return Options{
+		RowSeparator:          RowSeparator,
+		ColumnSeparator:       ColumnSeparator,
+		Placeholder:           Placeholder,
+		BlankFilling:          BlankFilling,
+		BlankFillingForHeader: BlankFillingForHeader,
+		ColOverflow:           ColOverflow,
+		UseBoard:              UseBoard,
+		SpaceAlt:              SpaceAlt,
+		OverFlowSeparator:     OverFlowSeparator,
+		CenterFilling:         CenterFilling,
+		IgnoreEmptyHeader:     IgnoreEmptyHeader,
+		NilText:               NilText,
+		AmbiguousWidth:        AmbiguousWidth,
+		TabWidth:              TabWidth,
+		EscapeControl:         EscapeControl,
+		Hyperlinks:            Hyperlinks,
+		EmptyText:             EmptyText,
+		EmptyBorder:           EmptyBorder,
+		Strict:                Strict,
+		ColOverflowMode:       ColOverflowMode,
+		StreamSample:          StreamSample,
+		BigPrecision:          BigPrecision,
+		BigGrouping:           BigGrouping,
+		BoolText:              BoolText,
+		BinaryEncoding:        BinaryEncoding,
+		BinaryMaxBytes:        BinaryMaxBytes,
+		MiddleEllipsis:        MiddleEllipsis,
+		NoHeader:              NoHeader,
+		SubTables:             SubTables,
+		NoColor:               NoColor,
+		LegacyConsole:         LegacyConsole,
+		Rounding:              Rounding,
+		Accounting:            Accounting,
+		NegativeStyle:         NegativeStyle,
+		Deterministic:         Deterministic,
+		Sanitize:              Sanitize,
+		EscapeFormulas:        EscapeFormulas,
+		LineEnding:            LineEnding,
+		ASCIIOnly:             ASCIIOnly,
+		Input:                 Input,
+		NaturalSort:           NaturalSort,
+		SortFold:              SortFold,
+		SortLocale:            SortLocale,
+	}
+}
+
+//replace all the options
+func WithOptions(o Options) Option {
+	return func(this *Options) {
+		*this = o
+	}
+}
+
+//rename headers for one call, such as localized names
+func WithHeaderNames(names map[string]string) Option {
+	return func(this *Options) {
+		this.HeaderNames = names
+	}
+}
+
+//show only the named columns for one call, fields of a single struct are selected too
+func WithColumns(names ...string) Option {
+	return func(this *Options) {
+		this.Columns = append([]string{}, names...)
+	}
+}
+
+//hide the named columns for one call
+func WithoutColumns(names ...string) Option {
+	return func(this *Options) {
+		this.ExcludeColumns = append(this.ExcludeColumns[:len(this.ExcludeColumns):len(this.ExcludeColumns)], names...)
+	}
+}
+
+//show units or descriptions under the named headers for one call
+func WithUnits(units map[string]string) Option {
+	return func(this *Options) {
+		this.Units = units
+	}
+}
+
+//color cell backgrounds of the named numeric columns by value for one call, needs truecolor terminal
+func WithHeatmap(names ...string) Option {
+	return func(this *Options) {
+		this.Heatmap = append(this.Heatmap[:len(this.Heatmap):len(this.Heatmap)], names...)
+	}
+}
+
+//draw lines after the data rows of indexes from 0 for one call
+func WithSeparatorsAfter(rows ...int) Option {
+	return func(this *Options) {
+		this.SectionRows = append(this.SectionRows[:len(this.SectionRows):len(this.SectionRows)], rows...)
+	}
+}
+
+//draw a line when the value of the named column changes for one call
+func WithSectionsBy(name string) Option {
+	return func(this *Options) {
+		this.SectionColumn = name
+	}
+}
+
+//show text in empty and nil cells of the named columns for one call, such as "-" or "n/a"
+func WithEmptyValues(values map[string]string) Option {
+	return func(this *Options) {
+		this.EmptyValues = values
+	}
+}
+
+//fill cells of the named columns with runes for one call, such as '.' for leader lines
+func WithPadRunes(pads map[string]rune) Option {
+	return func(this *Options) {
+		this.PadRunes = pads
+	}
+}
+
+//use the same column widths for every table, such as separately rendered pages,
+//longer cells are cut with … or wrapped when WrapCells is set
+func WithFixedLayout(widths ...int) Option {
+	return func(this *Options) {
+		this.FixedWidths = append([]int{}, widths...)
+	}
+}
+
+//limit every column to width cells for one call, longer cells are cut with … or wrapped when WrapCells is set
+func WithMaxWidth(width int) Option {
+	return func(this *Options) {
+		this.MaxWidth = width
+	}
+}
+
+/*
+Formatter with immutable options
+
+Description: A Formatter never reads the global options after
+	it is created, so formatters with different settings can
+	be used from many goroutines at the same time. For example:
+
+	f := table.NewFormatter(func(o *table.Options) {
+		o.ColumnSeparator = ","
+	})
+	str := f.Format(obj)
+*/
+type Formatter struct {
+	options Options
+}
+
+//create formatter from the current global options and opts
+func NewFormatter(opts ...Option) *Formatter {
+	o := Defaults()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Formatter{options: o}
+}
+
+//options of formatter
+func (this *Formatter) Options() Options {
+	return this.options
+}
+
+//format with the formatter's options, opts only affect this call
+func (this *Formatter) Format(obj interface{}, opts ...Option) string {
+	return newState(this.options, opts).run(obj)
+}
+
+//format obj to w in chunks with the formatter's options
+func (this *Formatter) Fprint(w io.Writer, obj interface{}, opts ...Option) error {
+	return newState(this.options, opts).runWriter(w, obj)
+}
+
+//append format of obj to dst with the formatter's options
+func (this *Formatter) AppendFormat(dst []byte, obj interface{}, opts ...Option) []byte {
+	return newState(this.options, opts).appendRun(dst, obj)
+}
+
+//format with the formatter's options, abort when ctx is done
+func (this *Formatter) FormatContext(ctx context.Context, obj interface{}, opts ...Option) (string, error) {
+	return newState(this.options, opts).runContext(ctx, obj)
+}
+
+//format as html table with the formatter's options
+func (this *Formatter) FormatHTML(obj interface{}, opts ...Option) string {
+	return newState(this.options, opts).runHTML(obj)
+}
+
+//format obj with the cells changed since prev styled
+func (this *Formatter) FormatChanges(prev, obj interface{}, opts ...Option) string {
+	return newState(this.options, opts).runChanges(prev, obj)
+}
+
+//format tables with shared column widths with the formatter's options
+func (this *Formatter) FormatTables(objs []interface{}, opts ...Option) []string {
+	return formatTables(this.options, objs, opts)
+}
+
+//quick print
+func (this *Formatter) Print(obj interface{}, opts ...Option) {
+	this.Fprint(os.Stdout, obj, append([]Option{WithOutput(os.Stdout, nil)}, opts...)...)
+}
+
+//state of one format call
+type state struct {
+	Options
+	grid
+
+	//nil when the call can not be canceled
+	ctx context.Context
+
+	//styles of cell in row of grid, the first non-empty style is used,
+	//empty style means the row's style
+	marks []func(row, col int, val string) Style
+
+	//destination of chunks written while formatting, nil means the whole output is buffered
+	out io.Writer
+}
+
+//copy options and apply opts
+func newState(o Options, opts []Option) *state {
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.applyEnv()
+	return &state{Options: o}
+}
+
+//encode and format object
+func (this *state) run(obj interface{}) string {
+	if this.SubTables {
+		if str, ok := this.runSubTables(obj); ok {
+			return str
+		}
+	}
+	if this.Renderer != nil {
+		return this.runRenderer(obj)
+	}
+	this.encode(obj)
+	return this.format()
+}
+
+//encode object and append its format to dst
+func (this *state) appendRun(dst []byte, obj interface{}) []byte {
+	if this.SubTables || this.Renderer != nil {
+		return append(dst, this.run(obj)...)
+	}
+	this.encode(obj)
+	buf := bytes.NewBuffer(dst)
+	this.formatTo(buf)
+	return buf.Bytes()
+}
+
+//encode objects and format them with the widest columns of all
+func formatTables(base Options, objs []interface{}, opts []Option) []string {
+	states := make([]*state, len(objs))
+	tables := make([][][]string, len(objs))
+	widths := make([][]int, len(objs))
+	var shared []int
+	for i, obj := range objs {
+		s := newState(base, opts)
+		s.encode(obj)
+		states[i] = s
+		if s.isEmpty() && !s.EmptyBorder {
+			continue
+		}
+
+		tables[i], widths[i] = s.layout()
+		for col, w := range widths[i] {
+			if col == len(shared) {
+				shared = append(shared, 0)
+			}
+			if w > shared[col] {
+				shared[col] = w
+			}
+		}
+	}
+
+	strs := make([]string, len(objs))
+	for i, s := range states {
+		if tables[i] == nil {
+			strs[i] = s.EmptyText + s.newline()
+			continue
+		}
+		copy(widths[i], shared)
+		strs[i] = s.render(tables[i], widths[i])
+	}
+	return strs
+}
+
+//encode object and format as html
+func (this *state) runHTML(obj interface{}) string {
+	this.encode(obj)
+	return this.formatHTML()
+}
+
+//rows between two cancellation checks
+const checkBatch = 1024
+
+//panic value of a canceled call
+type abort struct {
+	err error
+}
+
+//encode and format object, stop between row batches when ctx is done
+func (this *state) runContext(ctx context.Context, obj interface{}) (str string, err error) {
+	if err = ctx.Err(); err != nil {
+		return "", err
+	}
+
+	this.ctx = ctx
+	defer func() {
+		if r := recover(); r != nil {
+			a, ok := r.(abort)
+			if !ok {
+				panic(r)
+			}
+			str, err = "", a.err
+		}
+	}()
+
+	return this.run(obj), nil
+}
+
+//run f and return the error it aborts with, such as *SchemaError of records encoded outside encode
+func catch(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			a, ok := r.(abort)
+			if !ok {
+				panic(r)
+			}
+			err = a.err
+		}
+	}()
+	f()
+	return nil
+}
+
+//abort the call if ctx is done
+func (this *state) check() {
+	if this.ctx == nil {
+		return
+	}
+	if err := this.ctx.Err(); err != nil {
+		panic(abort{err})
+	}
+}
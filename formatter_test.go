@@ -0,0 +1,260 @@
+package table
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//formatters with different options used concurrently
+func TestFormatterConcurrent(t *testing.T) {
+	comma := NewFormatter(func(o *Options) { o.ColumnSeparator = "," })
+	star := NewFormatter(func(o *Options) { o.ColumnSeparator = "*" })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if str := comma.Format("a,b\n1 2,3"); !strings.Contains(str, "1 2") {
+				t.Errorf("comma separator lost:\n%s", str)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if str := star.Format("a*b\n1,2*3"); !strings.Contains(str, "1,2") {
+				t.Errorf("star separator lost:\n%s", str)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+//per call options do not leak into globals
+func TestFormatOptions(t *testing.T) {
+	str := Format("a b", func(o *Options) { o.UseBoard = false })
+	if strings.Contains(str, "│") {
+		t.Errorf("UseBoard option ignored:\n%s", str)
+	}
+	if !UseBoard {
+		t.Errorf("call option changed global UseBoard")
+	}
+}
+
+//canceled call returns the context error
+func TestFormatContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	str, err := FormatContext(ctx, []int{1, 2, 3})
+	if err != nil || !strings.Contains(str, "│ 3 │ 3 │") {
+		t.Errorf("unexpected result %v:\n%s", err, str)
+	}
+
+	cancel()
+	list := make([]int, 10*checkBatch)
+	if _, err := FormatContext(ctx, list); err != context.Canceled {
+		t.Errorf("expect context.Canceled, got %v", err)
+	}
+}
+
+//context canceled after some checks
+type countCtx struct {
+	context.Context
+	left int
+}
+
+func (this *countCtx) Err() error {
+	if this.left--; this.left < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+//cancel while rows are encoded
+func TestFormatContextBatches(t *testing.T) {
+	ctx := &countCtx{Context: context.Background(), left: 3}
+	list := make([]int, 10*checkBatch)
+	if _, err := FormatContext(ctx, list); err != context.Canceled {
+		t.Errorf("expect context.Canceled, got %v", err)
+	}
+}
+
+//header renaming of one call
+func TestWithHeaderNames(t *testing.T) {
+	type Person struct {
+		Name string `table:"name"`
+		Age  int
+	}
+	names := WithHeaderNames(map[string]string{"name": "姓名", "Age": "年龄"})
+	str := Format([]Person{{"alice", 30}}, names, func(o *Options) { o.UseBoard = false })
+	if str != "    姓名   年龄 \n 1  alice   30  \n" {
+		t.Errorf("list:\n%q", str)
+	}
+
+	//fields of single struct
+	str = Format(Person{"bob", 4}, names, func(o *Options) { o.UseBoard = false })
+	if str != " 姓名  bob \n 年龄   4  \n" {
+		t.Errorf("struct:\n%q", str)
+	}
+
+	//data is not renamed
+	if str := Format("name\nAge", names); strings.Count(str, "Age") != 1 {
+		t.Errorf("data renamed:\n%s", str)
+	}
+
+	//other calls are not affected
+	if str := Format(Person{"bob", 4}); !strings.Contains(str, "name") {
+		t.Errorf("names leaked:\n%s", str)
+	}
+}
+
+//column selection of one call
+func TestWithColumns(t *testing.T) {
+	type Log struct {
+		Name  string
+		Time  int
+		Debug string `table:",,,raw"`
+	}
+	logs := []Log{{"a", 1, "x\ny"}, {"b", 2, "z"}}
+	plain := func(o *Options) { o.UseBoard = false }
+
+	str := Format(logs, WithColumns("Name", "Time"), plain)
+	if str != "    Name  Time \n 1   a     1   \n 2   b     2   \n" {
+		t.Errorf("columns:\n%q", str)
+	}
+	if Format(logs, WithoutColumns("Debug"), plain) != str {
+		t.Errorf("without columns:\n%q", Format(logs, WithoutColumns("Debug"), plain))
+	}
+
+	//raw flags follow the selected columns
+	str = Format(logs, WithoutColumns("Name", "Time"), plain)
+	if str != "    Debug \n 1    x   \n      y   \n 2    z   \n" {
+		t.Errorf("raw column:\n%q", str)
+	}
+
+	//fields of single struct
+	if str := Format(logs[1], WithColumns("Time"), plain); str != " Time  2 \n" {
+		t.Errorf("struct:\n%q", str)
+	}
+
+	//headers of strings
+	if str := Format("a b c\n1 2 3", WithoutColumns("b"), plain); str != " a  c \n 1  3 \n" {
+		t.Errorf("string:\n%q", str)
+	}
+
+	//calls of a formatter do not share the excluded names
+	f := NewFormatter(plain, WithoutColumns("A"), WithoutColumns("B"), WithoutColumns("C"))
+	done := make(chan string)
+	for _, name := range []string{"Name", "Time"} {
+		go func(name string) {
+			done <- f.Format(logs, WithoutColumns(name))
+		}(name)
+	}
+	for i := 0; i < 2; i++ {
+		if str := <-done; strings.Contains(str, "Name") == strings.Contains(str, "Time") {
+			t.Errorf("concurrent calls:\n%q", str)
+		}
+	}
+}
+
+//ragged rows in strict mode
+func TestStrict(t *testing.T) {
+	strict := func(o *Options) { o.Strict = true }
+
+	_, err := FormatContext(context.Background(), "a b\n1 2\n3 4 5", strict)
+	rowErr, ok := err.(*RowError)
+	if !ok || rowErr.Row != 2 || rowErr.Expected != 2 || len(rowErr.Fields) != 3 {
+		t.Fatalf("row error expected, got %v", err)
+	}
+	if err.Error() != `table: row 2 has 3 cells, header has 2: ["3" "4" "5"]` {
+		t.Errorf("message: %s", err)
+	}
+
+	//ignored empty header still decides columns
+	if _, err := FormatContext(context.Background(), "_ _\n1", strict); err == nil {
+		t.Errorf("short row accepted")
+	}
+
+	//Format shows the error
+	if str := Format("a b\n1", strict); !strings.Contains(str, "row 1 has 1 cells") {
+		t.Errorf("error not shown:\n%s", str)
+	}
+
+	//regular rows
+	if _, err := FormatContext(context.Background(), []int{1, 2}, strict); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+//hidden header
+func TestNoHeader(t *testing.T) {
+	type Row struct {
+		Name  string
+		Value int `table:",,unit=ms"`
+	}
+	rows := []Row{{"a", 1}, {"bb", 22}}
+	noHeader := func(o *Options) { o.NoHeader = true }
+
+	expected := "" +
+		"┌───┬────┬────┐\n" +
+		"│ 1 │ a  │ 1  │\n" +
+		"├───┼────┼────┤\n" +
+		"│ 2 │ bb │ 22 │\n" +
+		"└───┴────┴────┘\n"
+	if str := Format(rows, noHeader); str != expected {
+		t.Errorf("box:\n%s", str)
+	}
+	if str := Format(rows, noHeader, WithTheme(ThemeMarkdown)); str != "| 1 | a  | 1  |\n| 2 | bb | 22 |\n" {
+		t.Errorf("markdown:\n%q", str)
+	}
+}
+
+//columns of tables line up
+func TestFormatTables(t *testing.T) {
+	type Item struct {
+		Name  string
+		Price int
+	}
+	groups := []interface{}{
+		[]Item{{"apple", 3}},
+		[]Item{{"kiwi", 12345}},
+	}
+
+	strs := FormatTables(groups, WithTheme(ThemeCompact))
+	expected := []string{
+		"   │ Name  │ Price \n───┼───────┼───────\n 1 │ apple │ 3     \n",
+		"   │ Name  │ Price \n───┼───────┼───────\n 1 │ kiwi  │ 12345 \n",
+	}
+	for i := range expected {
+		if strs[i] != expected[i] {
+			t.Errorf("table %d:\n%q", i, strs[i])
+		}
+	}
+}
+
+//titled tables of map keys
+func TestSubTables(t *testing.T) {
+	type Pod struct {
+		Name   string
+		Status string
+	}
+	pods := map[string][]Pod{
+		"kube-system": {{"dns", "Running"}},
+		"default":     {{"web-1", "Running"}, {"web-2", "Pending"}},
+	}
+
+	expected := "" +
+		"default\n" +
+		"    Name   Status  \n" +
+		" 1  web-1  Running \n" +
+		" 2  web-2  Pending \n" +
+		"\n" +
+		"kube-system\n" +
+		"    Name   Status  \n" +
+		" 1   dns   Running \n"
+	str := Format(pods, WithTheme(ThemePlain), func(o *Options) { o.SubTables = true })
+	if str != expected {
+		t.Errorf("sub tables:\n%q", str)
+	}
+}
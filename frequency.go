@@ -0,0 +1,62 @@
+package table
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+//count and percent of keys of list elements, most frequent first, nil key means the element's cell,
+//such as table.Frequency(users, func(u interface{}) string { return u.(User).Country })
+func Frequency(list interface{}, key func(item interface{}) string, opts ...Option) string {
+	return NewFormatter(opts...).Frequency(list, key)
+}
+
+//frequency table with the formatter's options
+func (this *Formatter) Frequency(list interface{}, key func(item interface{}) string, opts ...Option) string {
+	s := newState(this.options, opts)
+	keys, counts := s.countKeys(reflect.ValueOf(list), key)
+
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		if x, y := counts[keys[i]], counts[keys[j]]; x != y {
+			return x > y
+		}
+		return s.compare(keys[i], keys[j]) < 0
+	})
+
+	s.addRow([]string{"value", "count", "percent"})
+	for _, k := range keys {
+		percent := strconv.FormatFloat(100*float64(counts[k])/float64(total), 'f', 1, 64) + "%"
+		s.addRow([]string{k, strconv.Itoa(counts[k]), percent})
+	}
+	return s.format()
+}
+
+//keys in order of first occurrence and their counts
+func (this *state) countKeys(v reflect.Value, key func(item interface{}) string) (keys []string, counts map[string]int) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	counts = map[string]int{}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, counts
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		var k string
+		if key != nil {
+			k = key(v.Index(i).Interface())
+		} else {
+			k = this.encodeCell(v.Index(i))
+		}
+		if counts[k] == 0 {
+			keys = append(keys, k)
+		}
+		counts[k]++
+	}
+	return keys, counts
+}
@@ -0,0 +1,28 @@
+package table
+
+import (
+	"testing"
+)
+
+//counts of derived keys and of elements
+func TestFrequency(t *testing.T) {
+	type User struct {
+		Name    string
+		Country string
+	}
+	users := []User{{"ann", "de"}, {"bob", "fr"}, {"cid", "de"}, {"dan", "at"}}
+	country := func(u interface{}) string { return u.(User).Country }
+
+	expected := "" +
+		" value  count  percent \n" +
+		"  de      2     50.0%  \n" +
+		"  at      1     25.0%  \n" +
+		"  fr      1     25.0%  \n"
+	if str := Frequency(users, country, WithTheme(ThemePlain)); str != expected {
+		t.Errorf("key:\n%q", str)
+	}
+
+	if str := Frequency([]int{3, 1, 3}, nil, WithTheme(ThemePlain)); str != " value  count  percent \n   3      2     66.7%  \n   1      1     33.3%  \n" {
+		t.Errorf("elements:\n%q", str)
+	}
+}
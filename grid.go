@@ -0,0 +1,705 @@
+package table
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+//normalized table, column widths are tracked while rows are added
+type grid struct {
+	cells  [][]string
+	widths []int
+	colNum int
+	seen   bool
+
+	//the empty header is ignored, the first row is data
+	headless bool
+
+	//cells of header before selection
+	fieldNum int
+	//indexes of selected columns, nil means all
+	selected []int
+
+	//key=value table tags of header names, such as unit=ms
+	tagParams map[string]map[string]string
+	//a unit row follows header
+	unitRow bool
+	//header names before renaming
+	names []string
+
+	//empty texts of columns, nil when no column has one
+	empties []string
+
+	//labels of column cells, nil when no column has one
+	labels []map[string]string
+
+	//padding runes of columns, nil when no column has one
+	pads []rune
+
+	//fixed and max widths of columns, 0 means the content decides, nil when no column has one
+	fixed []int
+	maxes []int
+
+	//rows are written while they come, columns are never hidden
+	stream bool
+
+	//the first column is row numbers of list elements
+	indexed bool
+
+	//the last row is footer of footer tags
+	footer bool
+
+	//index of struct row converted by ConvertableV2
+	structRow int
+
+	//alignments, formats and footers of Schema columns, nil when no column has one
+	aligns  []Align
+	formats []func(cell string) string
+	footers []string
+
+	//types of Schema columns validating data cells, nil when no column has one
+	types []ColumnType
+	//row and column of cells not fitting types, highlighted by InvalidStyle
+	invalid map[[2]int]bool
+
+	//data rows replaced by the elision or sampling row spanning all columns, 0 means none
+	omitted int
+	elided  int
+
+	//rows and columns of cells before layout, -1 for rows such as footer, nil means they are not tracked
+	rowOrigins []int
+	colOrigins []int
+}
+
+//drop all the rows
+func (this *state) reset() {
+	this.grid = grid{}
+}
+
+//reserve space for more rows
+func (this *state) grow(n int) {
+	if cap(this.cells)-len(this.cells) < n {
+		cells := make([][]string, len(this.cells), len(this.cells)+n)
+		copy(cells, this.cells)
+		this.cells = cells
+	}
+}
+
+//normalize row to the header's length and add it, blank rows are skipped
+func (this *state) addRow(fields []string) {
+	this.addCells(fields, nil)
+}
+
+//add row, raw cells are kept as is without placeholder and space handling,
+//line breaks make multi-line cells
+func (this *state) addCells(fields []string, raw []bool) {
+	if len(fields) == 0 {
+		return
+	}
+	if len(this.cells)%checkBatch == 0 {
+		this.check()
+	}
+
+	//the first row decides columns
+	if !this.seen {
+		this.seen = true
+		this.colNum = len(fields)
+		this.fieldNum = len(fields)
+		this.widths = make([]int, this.colNum)
+
+		//process empty header
+		if this.IgnoreEmptyHeader && this.isEmptyHeader(fields) {
+			this.headless = true
+			if this.Schema != nil || this.Layout != "" {
+				this.applySchema(nil)
+			}
+			return
+		}
+
+		//select columns by header
+		if this.Columns != nil || len(this.ExcludeColumns) != 0 {
+			this.selectColumns(fields)
+		}
+	} else if this.Strict && len(fields) != this.fieldNum {
+		row := len(this.cells) - this.headRows() + 1
+		panic(abort{&RowError{Row: row, Fields: fields, Expected: this.fieldNum}})
+	} else if this.types != nil && len(fields) != this.fieldNum {
+		row := len(this.cells) - this.headRows() + 1
+		reason := fmt.Sprintf("%d cells, header has %d", len(fields), this.fieldNum)
+		panic(abort{&SchemaError{Row: row, Reason: reason}})
+	}
+	if this.selected != nil {
+		fields, raw = this.project(fields, raw)
+	}
+	this.addLine(fields, raw)
+}
+
+//add normalized row after column selection
+func (this *state) addLine(fields []string, raw []bool) {
+	//overflow modes cutting the row
+	var extra []string
+	if len(fields) > this.colNum && this.colNum > 0 {
+		switch this.overflowMode() {
+		case OverflowWrap:
+			fields, extra = fields[:this.colNum], fields[this.colNum:]
+		case OverflowMarker:
+			marker := "…+" + strconv.Itoa(len(fields)-this.colNum) + " cols"
+			last := fields[this.colNum-1] + this.OverFlowSeparator + marker
+			fields = concat(fields[:this.colNum-1], []string{last})
+		}
+	}
+
+	//fillings
+	filling := this.BlankFilling
+	if len(this.cells) == 0 {
+		filling = this.BlankFillingForHeader
+	}
+	header := len(this.cells) == 0 && !this.headless
+	names := fields
+
+	//init row as blank filling
+	line := make([]string, this.colNum)
+	for index := range line {
+		line[index] = this.emptyText(index, filling)
+	}
+
+	//set fields
+	for col, val := range fields {
+		if this.Sanitize {
+			val = sanitize(val)
+		}
+		if col < len(raw) && raw[col] && col < this.colNum {
+			line[col] = strings.Replace(val, "\r\n", "\n", -1)
+			if this.ASCIIOnly {
+				line[col] = transliterate(line[col])
+			}
+			continue
+		}
+
+		//rename header, label data
+		if header {
+			val = this.headerName(val)
+		} else {
+			if col < len(this.types) {
+				this.validate(col, val)
+			}
+			val = this.accounting(this.formatCell(col, this.label(col, val)))
+		}
+
+		//handle placeholder
+		if val == this.Placeholder {
+			val = filling
+		}
+		if val == "" && col < this.colNum {
+			val = this.emptyText(col, val)
+		}
+
+		//handle column overflow
+		if col >= this.colNum {
+			if this.overflowMode() == OverflowMerge {
+				col = this.colNum - 1
+				val = line[col] + this.OverFlowSeparator + val
+			} else {
+				//discard more cols
+				break
+			}
+		}
+		line[col] = this.handleSpace(val)
+		if this.ASCIIOnly {
+			line[col] = transliterate(line[col])
+		}
+	}
+
+	//track max width
+	for col, val := range line {
+		if size := this.cellWidth(val); size > this.widths[col] {
+			this.widths[col] = size
+		}
+	}
+
+	this.cells = append(this.cells, line)
+
+	//continuation rows of wrapped columns
+	for len(extra) > 0 {
+		n := len(extra)
+		if n > this.colNum {
+			n = this.colNum
+		}
+		this.addLine(extra[:n], nil)
+		extra = extra[n:]
+	}
+
+	//units under header
+	if header {
+		this.names = names
+		this.addUnits(names)
+		this.addLabels(names)
+		this.addEmpties(names)
+		this.addPads(names)
+		this.fixed = this.tagInts("width", names)
+		this.maxes = this.tagInts("maxwidth", names)
+		if this.Schema != nil || this.Layout != "" {
+			this.applySchema(names)
+		}
+	}
+}
+
+//add unit row of header names when any column has unit
+func (this *state) addUnits(names []string) {
+	units := make([]string, len(names))
+	found := false
+	for col, name := range names {
+		unit, ok := this.Units[name]
+		if !ok {
+			unit, ok = this.tagParams["unit"][name]
+		}
+		if ok {
+			units[col] = unit
+			found = true
+		}
+	}
+	if !found {
+		return
+	}
+
+	this.unitRow = true
+	this.addLine(units, nil)
+}
+
+//rows of table starting a section, nil when there is no section
+func (this *state) sections(tb [][]string, head int) (starts []bool) {
+	if len(this.SectionRows) == 0 && this.SectionColumn == "" {
+		return nil
+	}
+	starts = make([]bool, len(tb))
+	for _, row := range this.SectionRows {
+		if row >= 0 && head+row+1 < len(tb) {
+			starts[head+row+1] = true
+		}
+	}
+
+	//value changes
+	for col, name := range this.names {
+		if name != this.SectionColumn {
+			continue
+		}
+		last := ""
+		for row := head; row < len(tb); row++ {
+			if val := tb[row][col]; val != "" {
+				starts[row] = starts[row] || row != head && val != last
+				last = val
+			}
+		}
+	}
+	return starts
+}
+
+//there is no data row and EmptyText is set
+func (this *state) isEmpty() bool {
+	return this.EmptyText != "" && len(this.cells) <= this.headRows()
+}
+
+//empty texts of header names
+func (this *state) addEmpties(names []string) {
+	for col, name := range names {
+		text, ok := this.EmptyValues[name]
+		if !ok {
+			text, ok = this.tagParams["empty"][name]
+		}
+		if ok {
+			if this.empties == nil {
+				this.empties = make([]string, len(names))
+			}
+			this.empties[col] = text
+		}
+	}
+}
+
+//padding runes of header names
+func (this *state) addPads(names []string) {
+	for col, name := range names {
+		pad, ok := this.PadRunes[name]
+		if tag, found := this.tagParams["pad"][name]; !ok && found {
+			pad, _ = utf8.DecodeRuneInString(tag)
+			ok = pad != utf8.RuneError
+		}
+		if ok {
+			if this.pads == nil {
+				this.pads = make([]rune, len(names))
+			}
+			this.pads[col] = pad
+		}
+	}
+}
+
+//positive integers of key=value tags of header names, nil when there is none
+func (this *state) tagInts(key string, names []string) (ints []int) {
+	for col, name := range names {
+		n, err := strconv.Atoi(this.tagParams[key][name])
+		if err != nil || n <= 0 {
+			continue
+		}
+		if ints == nil {
+			ints = make([]int, len(names))
+		}
+		ints[col] = n
+	}
+	return ints
+}
+
+//width limit of column, 0 means the content decides
+func (this *state) limit(col int) int {
+	if col < len(this.FixedWidths) && this.FixedWidths[col] > 0 {
+		return this.FixedWidths[col]
+	}
+	if col < len(this.fixed) && this.fixed[col] > 0 {
+		return this.fixed[col]
+	}
+	if col < len(this.maxes) && this.maxes[col] > 0 && this.widths[col] > this.maxes[col] {
+		return this.maxes[col]
+	}
+	if this.MaxWidth > 0 && this.widths[col] > this.MaxWidth {
+		return this.MaxWidth
+	}
+	return 0
+}
+
+//cut cells of columns with width limit
+func (this *state) applyLimits(colWidth []int) {
+	for col := range colWidth {
+		limit := this.limit(col)
+		if limit == 0 {
+			continue
+		}
+		colWidth[col] = limit
+		for row, line := range this.cells {
+			if !this.isElided(row) {
+				line[col] = this.fit(line[col], limit)
+			}
+		}
+	}
+}
+
+//cut or wrap every line of cell to w cells
+func (this *state) fit(val string, w int) string {
+	cut := this.truncate
+	if this.MiddleEllipsis {
+		cut = this.truncateMiddle
+	}
+	if this.WrapCells {
+		cut = this.wrap
+	}
+	if strings.IndexByte(val, '\n') < 0 {
+		return cut(val, w)
+	}
+	lines := strings.Split(val, "\n")
+	for i, line := range lines {
+		lines[i] = cut(line, w)
+	}
+	return strings.Join(lines, "\n")
+}
+
+//empty text of column for empty cell, filling when the column has none
+func (this *state) emptyText(col int, filling string) string {
+	if filling == "" && col < len(this.empties) && this.empties[col] != "" {
+		return this.empties[col]
+	}
+	return filling
+}
+
+//rows of header, header name and unit
+func (this *state) headRows() int {
+	switch {
+	case this.headless:
+		return 0
+	case this.unitRow:
+		return 2
+	}
+	return 1
+}
+
+//select columns of header, columns without name such as index are always kept
+func (this *state) selectColumns(header []string) {
+	this.selected = []int{}
+	for col, name := range header {
+		if name == this.Placeholder || this.isSelected(name) {
+			this.selected = append(this.selected, col)
+		}
+	}
+	this.colNum = len(this.selected)
+	this.widths = make([]int, this.colNum)
+}
+
+//field is shown by Columns and ExcludeColumns
+func (this *state) isSelected(name string) bool {
+	if this.Columns != nil && !contains(this.Columns, name) {
+		return false
+	}
+	return !contains(this.ExcludeColumns, name)
+}
+
+//selected cells of row, cells longer than header are dropped
+func (this *state) project(fields []string, raw []bool) (selFields []string, selRaw []bool) {
+	selFields = make([]string, 0, len(this.selected))
+	for _, col := range this.selected {
+		if col < len(fields) {
+			selFields = append(selFields, fields[col])
+		}
+	}
+	if raw != nil {
+		selRaw = make([]bool, 0, len(this.selected))
+		for _, col := range this.selected {
+			if col < len(raw) {
+				selRaw = append(selRaw, raw[col])
+			}
+		}
+	}
+	return selFields, selRaw
+}
+
+//index of str in list, -1 when there is none
+func indexOf(list []string, str string) int {
+	for i, s := range list {
+		if s == str {
+			return i
+		}
+	}
+	return -1
+}
+
+//str is one of list
+func contains(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+//header name after renaming
+func (this *state) headerName(name string) string {
+	if alt, ok := this.HeaderNames[name]; ok {
+		return alt
+	}
+	return name
+}
+
+//how to handle more columns than header
+type OverflowMode int
+
+const (
+	//ColOverflow decides between merge and discard
+	OverflowAuto OverflowMode = iota
+	//join more columns into the last column by OverFlowSeparator
+	OverflowMerge
+	//discard more columns
+	OverflowDiscard
+	//put more columns on continuation rows
+	OverflowWrap
+	//discard more columns with a marker such as "…+3 cols" in the last column
+	OverflowMarker
+)
+
+//overflow mode of call
+func (this *state) overflowMode() OverflowMode {
+	switch {
+	case this.ColOverflowMode != OverflowAuto:
+		return this.ColOverflowMode
+	case this.ColOverflow:
+		return OverflowMerge
+	}
+	return OverflowDiscard
+}
+
+//row of different length in strict mode
+type RowError struct {
+	//data row from 1
+	Row      int
+	Fields   []string
+	Expected int
+}
+
+func (this *RowError) Error() string {
+	return fmt.Sprintf("table: row %d has %d cells, header has %d: %q", this.Row, len(this.Fields), this.Expected, this.Fields)
+}
+
+//all header fields are placeholder
+func (this *state) isEmptyHeader(header []string) bool {
+	for _, val := range header {
+		if val != this.Placeholder {
+			return false
+		}
+	}
+	return true
+}
+
+//cells and max width of columns
+func (this *state) layout() (tb [][]string, colWidth []int) {
+	if this.SortColumns != nil && !this.stream {
+		this.sortRows()
+	}
+	if this.tagParams["hidewhenempty"] != nil && !this.stream {
+		this.hideEmpty()
+	}
+	if (this.tagParams["footer"] != nil || this.footers != nil) && !this.stream && !this.footer {
+		this.addFooter()
+	}
+	if (this.SampleEvery > 1 || this.SampleSize > 0) && !this.stream && this.omitted == 0 {
+		this.sample()
+	}
+	if (this.HeadRows > 0 || this.TailRows > 0) && !this.stream && this.omitted == 0 {
+		this.elide()
+	}
+	if this.NoHeader && !this.headless {
+		this.dropHeader()
+	}
+
+	//EmptyText is the only cell
+	if this.isEmpty() {
+		this.headless, this.unitRow, this.names = true, false, nil
+		return [][]string{{this.EmptyText}}, []int{this.cellWidth(this.EmptyText)}
+	}
+
+	//handle empty table
+	if len(this.cells) == 0 {
+		//use place holder to represent a empty table
+		this.headless = true
+		return [][]string{{this.BlankFillingForHeader}}, []int{this.width(this.BlankFillingForHeader)}
+	}
+
+	colWidth = make([]int, len(this.widths))
+	copy(colWidth, this.widths)
+	if this.fixed != nil || this.maxes != nil || this.FixedWidths != nil || this.MaxWidth > 0 {
+		this.applyLimits(colWidth)
+	}
+	return this.cells, colWidth
+}
+
+//remove columns of hidewhenempty tag without data
+func (this *state) hideEmpty() {
+	head := this.headRows()
+	for col := len(this.names) - 1; col >= 0; col-- {
+		if _, ok := this.tagParams["hidewhenempty"][this.names[col]]; !ok || col >= this.colNum {
+			continue
+		}
+		empty := true
+		for _, line := range this.cells[head:] {
+			if val := line[col]; val != "" && val != this.emptyText(col, "") {
+				empty = false
+				break
+			}
+		}
+		if empty {
+			this.deleteColumn(col)
+		}
+	}
+}
+
+//remove column of all rows and its state
+func (this *grid) deleteColumn(col int) {
+	if col == 0 {
+		this.indexed = false
+	}
+	if col < len(this.colOrigins) {
+		this.colOrigins = append(this.colOrigins[:col:col], this.colOrigins[col+1:]...)
+	}
+	for row, line := range this.cells {
+		this.cells[row] = deleteString(line, col)
+	}
+	if col < len(this.widths) {
+		this.widths = append(this.widths[:col:col], this.widths[col+1:]...)
+	}
+	if col < this.colNum {
+		this.colNum--
+	}
+
+	this.names = deleteString(this.names, col)
+	this.empties = deleteString(this.empties, col)
+	if col < len(this.labels) {
+		this.labels = append(this.labels[:col:col], this.labels[col+1:]...)
+	}
+	if col < len(this.pads) {
+		this.pads = append(this.pads[:col:col], this.pads[col+1:]...)
+	}
+	if col < len(this.fixed) {
+		this.fixed = append(this.fixed[:col:col], this.fixed[col+1:]...)
+	}
+	if col < len(this.maxes) {
+		this.maxes = append(this.maxes[:col:col], this.maxes[col+1:]...)
+	}
+	if col < len(this.aligns) {
+		this.aligns = append(this.aligns[:col:col], this.aligns[col+1:]...)
+	}
+	if col < len(this.formats) {
+		this.formats = append(this.formats[:col:col], this.formats[col+1:]...)
+	}
+	this.footers = deleteString(this.footers, col)
+	if col < len(this.types) {
+		this.types = append(this.types[:col:col], this.types[col+1:]...)
+	}
+	if this.invalid != nil {
+		invalid := make(map[[2]int]bool, len(this.invalid))
+		for cell := range this.invalid {
+			if cell[1] == col {
+				continue
+			}
+			if cell[1] > col {
+				cell[1]--
+			}
+			invalid[cell] = true
+		}
+		this.invalid = invalid
+	}
+}
+
+//copy of s without element i, s is not changed
+func deleteString(s []string, i int) []string {
+	if i >= len(s) {
+		return s
+	}
+	return append(s[:i:i], s[i+1:]...)
+}
+
+//remove header rows, widths are decided by data rows
+func (this *state) dropHeader() {
+	head := this.headRows()
+	this.cells = this.cells[head:]
+	this.moveRows(func(row int) int { return row - head })
+	this.headless, this.unitRow = true, false
+	this.measure()
+}
+
+//measure widths of columns again, elision row is not measured
+func (this *state) measure() {
+	for col := range this.widths {
+		this.widths[col] = 0
+	}
+	for row, line := range this.cells {
+		if this.isElided(row) {
+			continue
+		}
+		for col, val := range line {
+			if size := this.cellWidth(val); size > this.widths[col] {
+				this.widths[col] = size
+			}
+		}
+	}
+}
+
+//width of the widest line of cell
+func (this *state) cellWidth(val string) int {
+	if strings.IndexByte(val, '\n') < 0 {
+		return this.width(val)
+	}
+
+	max := 0
+	for _, line := range strings.Split(val, "\n") {
+		if size := this.width(line); size > max {
+			max = size
+		}
+	}
+	return max
+}
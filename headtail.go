@@ -0,0 +1,88 @@
+package table
+
+import (
+	"strconv"
+)
+
+//show the first head and the last tail data rows with a row telling the omitted ones, such as huge datasets
+func WithHeadTail(head, tail int) Option {
+	return func(this *Options) {
+		this.HeadRows, this.TailRows = head, tail
+	}
+}
+
+//replace data rows between HeadRows and TailRows with an elision row, footer is kept
+func (this *state) elide() {
+	head := this.headRows()
+	end := len(this.cells) - this.footRows()
+	omitted := end - head - this.HeadRows - this.TailRows
+	if omitted <= 0 {
+		return
+	}
+
+	mark := this.cutMark()
+	text := mark + " " + strconv.Itoa(omitted) + " rows omitted " + mark
+	if omitted == 1 {
+		text = mark + " 1 row omitted " + mark
+	}
+	line := make([]string, this.colNum)
+	line[0] = text
+
+	from, to := head+this.HeadRows, end-this.TailRows
+	cells := make([][]string, 0, len(this.cells)-omitted+1)
+	cells = append(cells, this.cells[:from]...)
+	cells = append(cells, line)
+	this.cells = append(cells, this.cells[to:]...)
+	this.moveRows(func(row int) int {
+		switch {
+		case row < from:
+			return row
+		case row < to:
+			return -1
+		}
+		return row - omitted + 1
+	})
+	this.omitted, this.elided = omitted, from
+	this.measure()
+}
+
+//change indexes of rows with invalid cells, elision row and origins, removed rows are moved to -1
+func (this *grid) moveRows(move func(row int) int) {
+	if this.rowOrigins != nil {
+		origins := make([]int, len(this.cells))
+		for row := range origins {
+			origins[row] = -1
+		}
+		for row, origin := range this.rowOrigins {
+			if to := move(row); to >= 0 && to < len(origins) {
+				origins[to] = origin
+			}
+		}
+		this.rowOrigins = origins
+	}
+	if this.invalid != nil {
+		invalid := make(map[[2]int]bool, len(this.invalid))
+		for cell := range this.invalid {
+			if row := move(cell[0]); row >= 0 {
+				invalid[[2]int{row, cell[1]}] = true
+			}
+		}
+		this.invalid = invalid
+	}
+	if this.omitted > 0 {
+		this.elided = move(this.elided)
+	}
+}
+
+//row and column of cell before layout, -1 when it has none
+func (this *grid) origin(row, col int) (int, int) {
+	if row >= len(this.rowOrigins) || col >= len(this.colOrigins) {
+		return -1, -1
+	}
+	return this.rowOrigins[row], this.colOrigins[col]
+}
+
+//elision row of HeadRows and TailRows or note row of sampling
+func (this *grid) isElided(row int) bool {
+	return this.omitted > 0 && row == this.elided
+}
@@ -0,0 +1,47 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+//first and last rows with elision row between them
+func TestHeadTail(t *testing.T) {
+	var rows strings.Builder
+	rows.WriteString("ID Name\n")
+	for i := 1; i <= 1000; i++ {
+		rows.WriteString(strconv.Itoa(i) + " user" + strconv.Itoa(i) + "\n")
+	}
+
+	str := Format(rows.String(), WithTheme(ThemeASCII), WithHeadTail(2, 1))
+	expected := "" +
+		"+------+---------------+\n" +
+		"|  ID  |     Name      |\n" +
+		"+------+---------------+\n" +
+		"|  1   |     user1     |\n" +
+		"+------+---------------+\n" +
+		"|  2   |     user2     |\n" +
+		"+------+---------------+\n" +
+		"| … 997 rows omitted … |\n" +
+		"+------+---------------+\n" +
+		"| 1000 |   user1000    |\n" +
+		"+------+---------------+\n"
+	if str != expected {
+		t.Errorf("head and tail:\n%q", str)
+	}
+
+	//footer of all rows, no tail
+	type Order struct {
+		Price int `table:",,footer=sum"`
+	}
+	orders := []Order{{1}, {2}, {3}}
+	if str := Format(orders, WithTheme(ThemePlain), WithHeadTail(1, 0)); str != "         Price      \n 1         1        \n … 2 rows omitted … \n           6        \n" {
+		t.Errorf("footer:\n%q", str)
+	}
+
+	//all rows fit
+	if str := Format("ID\n1\n2", WithTheme(ThemePlain), WithHeadTail(1, 1)); str != " ID \n 1  \n 2  \n" {
+		t.Errorf("all rows:\n%q", str)
+	}
+}
@@ -0,0 +1,64 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+)
+
+//range of numeric column
+type span struct {
+	low, high float64
+	ok        bool
+}
+
+//mark cells of heatmap columns with background colors, cells which are not numbers are not marked
+func (this *state) heatmap(tb [][]string) func(row, col int, val string) Style {
+	head := this.headRows()
+	spans := make([]span, len(this.names))
+	for col, name := range this.names {
+		if !contains(this.Heatmap, name) {
+			continue
+		}
+		for _, line := range tb[head:] {
+			n, err := strconv.ParseFloat(strings.TrimSpace(line[col]), 64)
+			if err != nil {
+				continue
+			}
+			s := &spans[col]
+			if !s.ok || n < s.low {
+				s.low = n
+			}
+			if !s.ok || n > s.high {
+				s.high = n
+			}
+			s.ok = true
+		}
+	}
+
+	return func(row, col int, val string) Style {
+		if row < head || col >= len(spans) || !spans[col].ok {
+			return ""
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		if err != nil {
+			return ""
+		}
+		s := spans[col]
+		ratio := 0.5
+		if s.high > s.low {
+			ratio = (n - s.low) / (s.high - s.low)
+		}
+		return heatStyle(ratio)
+	}
+}
+
+//black text on background from green through yellow to red, ratio is in [0, 1]
+func heatStyle(ratio float64) Style {
+	red, green := 255, 255
+	if ratio < 0.5 {
+		red = int(ratio * 2 * 255)
+	} else {
+		green = int((1 - ratio) * 2 * 255)
+	}
+	return Style("30;48;2;" + strconv.Itoa(red) + ";" + strconv.Itoa(green) + ";0")
+}
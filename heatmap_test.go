@@ -0,0 +1,30 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+//heatmap colors of numeric column
+func TestHeatmap(t *testing.T) {
+	type Host struct {
+		Name string
+		Load float64
+	}
+	hosts := []Host{{"a", 0}, {"b", 0.5}, {"c", 1}}
+
+	str := Format(hosts, WithHeatmap("Load"), WithHeaderNames(map[string]string{"Load": "负载"}))
+	for _, s := range []string{"\x1b[30;48;2;0;255;0m0\x1b[0m", "\x1b[30;48;2;255;255;0m0.5\x1b[0m", "\x1b[30;48;2;255;0;0m1\x1b[0m"} {
+		if !strings.Contains(str, s) {
+			t.Errorf("%q expected:\n%s", s, str)
+		}
+	}
+	if strings.Count(str, "\x1b[30;") != 3 {
+		t.Errorf("only load is colored:\n%q", str)
+	}
+
+	//same layout
+	if stripEscapes(str) != Format(hosts, WithHeaderNames(map[string]string{"Load": "负载"})) {
+		t.Errorf("layout changed:\n%s", str)
+	}
+}
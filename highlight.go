@@ -0,0 +1,56 @@
+package table
+
+import (
+	"regexp"
+)
+
+//style of matching cells when HighlightStyle is empty
+const defaultHighlightStyle Style = "7"
+
+//highlight data cells containing substr for one call
+func WithHighlight(substr string) Option {
+	return WithHighlightRegexp(regexp.MustCompile(regexp.QuoteMeta(substr)))
+}
+
+//highlight data cells matching re for one call, such as `^db-\d+$`
+func WithHighlightRegexp(re *regexp.Regexp) Option {
+	return func(this *Options) {
+		this.Highlight = re
+	}
+}
+
+//data cell matches Highlight, row numbers are not searched
+func (this *state) highlighted(col int, val string) bool {
+	return this.Highlight != nil && val != "" && !(this.indexed && col == 0) && this.Highlight.MatchString(val)
+}
+
+//put matching cells of data line between asterisks when colors are off, widths of their columns grow,
+//only text output is marked
+func (this *state) starHighlights(line []string, colWidth []int) {
+	if this.Highlight == nil || !this.noColor() {
+		return
+	}
+	for col, val := range line {
+		if !this.highlighted(col, val) {
+			continue
+		}
+		line[col] = "*" + val + "*"
+		if size := this.cellWidth(line[col]); size > colWidth[col] {
+			colWidth[col] = size
+		}
+	}
+}
+
+//mark matching data cells with HighlightStyle, footer is not searched
+func (this *state) highlights(head, foot int) func(row, col int, val string) Style {
+	style := this.HighlightStyle
+	if style == "" {
+		style = defaultHighlightStyle
+	}
+	return func(row, col int, val string) Style {
+		if row < head || row == foot || !this.highlighted(col, val) {
+			return ""
+		}
+		return style
+	}
+}
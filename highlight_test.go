@@ -0,0 +1,52 @@
+package table
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+//cells matching substring and regexp
+func TestHighlight(t *testing.T) {
+	plain := WithTheme(ThemePlain)
+	rows := "Host Port\ndb-01 5432\nweb-01 80"
+
+	expected := "  Host   Port \n \x1b[7mdb-01\x1b[0m   5432 \n web-01   80  \n"
+	if str := Format(rows, plain, WithHighlight("db")); str != expected {
+		t.Errorf("substring:\n%q", str)
+	}
+
+	expected = "  Host   Port \n db-01   \x1b[32m5432\x1b[0m \n web-01   80  \n"
+	if str := Format(rows, plain, WithHighlightRegexp(regexp.MustCompile(`^\d{4}$`)), func(o *Options) { o.HighlightStyle = "32" }); str != expected {
+		t.Errorf("regexp:\n%q", str)
+	}
+
+	//asterisks without colors, header is not searched
+	if str := Format(rows, plain, WithHighlightRegexp(regexp.MustCompile("os|web")), func(o *Options) { o.NoColor = true }); str != "   Host    Port \n  db-01    5432 \n *web-01*   80  \n" {
+		t.Errorf("no color:\n%q", str)
+	}
+
+	//row numbers are not searched, other renderers are not marked
+	type User struct {
+		Name  string
+		Score int
+	}
+	users := []User{{"alice", 9}, {"bob", 1}}
+	noColor := func(o *Options) { o.NoColor = true }
+	if str := Format(users, plain, WithHighlight("1"), noColor); str != "    Name   Score \n 1  alice    9   \n 2   bob    *1*  \n" {
+		t.Errorf("index:\n%q", str)
+	}
+	if str := Format(users, WithHighlight("1"), noColor, WithRenderer(CSVRenderer)); str != ",Name,Score\n1,alice,9\n2,bob,1\n" {
+		t.Errorf("csv:\n%q", str)
+	}
+
+	//streamed rows
+	var buf strings.Builder
+	s := NewStream(&buf, plain, WithHighlight("b"), noColor)
+	for _, u := range users {
+		s.Write(u)
+	}
+	if s.Close(); buf.String() != " Name   Score \n alice    9   \n *bob*    1   \n" {
+		t.Errorf("stream:\n%q", buf.String())
+	}
+}
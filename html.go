@@ -0,0 +1,94 @@
+package table
+
+import (
+	"bytes"
+	"html"
+	"sort"
+	"strings"
+)
+
+//attributes of a html element, such as {"class": "table"}
+type Attrs map[string]string
+
+//html table, rows and cells are escaped, line breaks of multi-line cells become <br>
+func (this *state) formatHTML() string {
+	tb, _ := this.layout()
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	//column attributes are decided by header
+	var header []string
+	if !this.headless {
+		header = tb[0]
+	}
+	cols := make([]Attrs, len(tb[0]))
+	if this.ColumnAttrs != nil {
+		for col := range cols {
+			name := ""
+			if header != nil {
+				name = header[col]
+			}
+			cols[col] = this.ColumnAttrs(col, name)
+		}
+	}
+
+	buf.WriteString("<table")
+	writeAttrs(buf, this.TableAttrs)
+	buf.WriteString(">\n")
+
+	head := this.headRows()
+	if head != 0 {
+		buf.WriteString("<thead>\n")
+		for _, line := range tb[:head] {
+			this.writeHTMLRow(buf, "th", -1, line, cols)
+		}
+		buf.WriteString("</thead>\n")
+	}
+	body := tb[head : len(tb)-this.footRows()]
+
+	buf.WriteString("<tbody>\n")
+	for row, line := range body {
+		if row%checkBatch == 0 {
+			this.check()
+		}
+		this.writeHTMLRow(buf, "td", row, line, cols)
+	}
+	buf.WriteString("</tbody>\n")
+	if this.footer {
+		buf.WriteString("<tfoot>\n")
+		this.writeHTMLRow(buf, "td", len(body), tb[len(tb)-1], cols)
+		buf.WriteString("</tfoot>\n")
+	}
+	buf.WriteString("</table>\n")
+	return buf.String()
+}
+
+//write row of th or td cells, header rows are -1
+func (this *state) writeHTMLRow(buf *bytes.Buffer, tag string, row int, line []string, cols []Attrs) {
+	buf.WriteString("<tr")
+	if this.RowAttrs != nil {
+		writeAttrs(buf, this.RowAttrs(row, line))
+	}
+	buf.WriteString(">")
+	for col, val := range line {
+		buf.WriteString("<" + tag)
+		writeAttrs(buf, cols[col])
+		buf.WriteString(">")
+		buf.WriteString(strings.Replace(html.EscapeString(val), "\n", "<br>", -1))
+		buf.WriteString("</" + tag + ">")
+	}
+	buf.WriteString("</tr>\n")
+}
+
+//write attributes sorted by name
+func writeAttrs(buf *bytes.Buffer, attrs Attrs) {
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		buf.WriteString(" " + name + "=\"" + html.EscapeString(attrs[name]) + "\"")
+	}
+}
@@ -0,0 +1,50 @@
+package table
+
+import (
+	"strconv"
+	"testing"
+)
+
+//html output and attribute hooks
+func TestFormatHTML(t *testing.T) {
+	type Item struct {
+		Name  string
+		Price int
+	}
+	items := []Item{{"<tea>", 3}, {"cake", 12}}
+
+	str := FormatHTML(items, func(o *Options) {
+		o.TableAttrs = Attrs{"class": "table", "id": "items"}
+		o.RowAttrs = func(row int, cells []string) Attrs {
+			if row < 0 {
+				return nil
+			}
+			return Attrs{"class": "row-" + strconv.Itoa(row%2)}
+		}
+		o.ColumnAttrs = func(col int, name string) Attrs {
+			if name == "Price" {
+				return Attrs{"class": "num"}
+			}
+			return nil
+		}
+	})
+	expected := "" +
+		"<table class=\"table\" id=\"items\">\n" +
+		"<thead>\n" +
+		"<tr><th></th><th>Name</th><th class=\"num\">Price</th></tr>\n" +
+		"</thead>\n" +
+		"<tbody>\n" +
+		"<tr class=\"row-0\"><td>1</td><td>&lt;tea&gt;</td><td class=\"num\">3</td></tr>\n" +
+		"<tr class=\"row-1\"><td>2</td><td>cake</td><td class=\"num\">12</td></tr>\n" +
+		"</tbody>\n" +
+		"</table>\n"
+	if str != expected {
+		t.Errorf("html:\n%s", str)
+	}
+
+	//no header
+	expected = "<table>\n<tbody>\n<tr><td>a</td><td>b</td></tr>\n</tbody>\n</table>\n"
+	if str := FormatHTML("_ _\na b"); str != expected {
+		t.Errorf("headless html:\n%s", str)
+	}
+}
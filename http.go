@@ -0,0 +1,74 @@
+package table
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+//media types of Handler and their renderers, html is a page and plain text is written in chunks
+var mediaTypes = []struct {
+	name     string
+	renderer Renderer
+}{
+	{"text/html", nil},
+	{"text/csv", CSVRenderer},
+	{"text/tab-separated-values", TSVRenderer},
+	{"text/plain", nil},
+}
+
+//handler formatting the value of get for every request, such as debug endpoints,
+//the one of text/html, text/csv, text/tab-separated-values and text/plain with the highest q in Accept header decides the format,
+//html is the default, colors are dropped
+func Handler(get func(r *http.Request) interface{}, opts ...Option) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s := newState(Defaults(), opts)
+		s.NoColor = true
+		obj := get(r)
+
+		name := acceptedType(r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", name+"; charset=utf-8")
+		w.Header().Add("Vary", "Accept")
+		switch name {
+		case "text/html":
+			io.WriteString(w, "<!DOCTYPE html>\n<html>\n<head><meta charset=\"utf-8\"></head>\n<body>\n")
+			io.WriteString(w, s.runHTML(obj))
+			io.WriteString(w, "</body>\n</html>\n")
+		case "text/plain":
+			s.runWriter(w, obj)
+		default:
+			for _, t := range mediaTypes {
+				if t.name == name {
+					s.Renderer = t.renderer
+				}
+			}
+			io.WriteString(w, s.run(obj))
+		}
+	})
+}
+
+//known media type of Accept header with the highest q, the first listed one of the same q,
+//q=0 excludes a type, text/html when there is none
+func acceptedType(accept string) string {
+	best, bestQ := "text/html", 0.0
+	for _, item := range strings.Split(accept, ",") {
+		params := strings.Split(item, ";")
+		name := strings.ToLower(strings.TrimSpace(params[0]))
+		q := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if len(param) > 2 && (param[0] == 'q' || param[0] == 'Q') && param[1] == '=' {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		for _, t := range mediaTypes {
+			if t.name == name && q > bestQ {
+				best, bestQ = name, q
+			}
+		}
+	}
+	return best
+}
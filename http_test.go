@@ -0,0 +1,46 @@
+package table
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+//formats of Accept header
+func TestHandler(t *testing.T) {
+	h := Handler(func(r *http.Request) interface{} { return "Name Size\na 1" }, WithTheme(ThemePlain))
+	get := func(accept string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", "/debug", nil)
+		r.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := get("text/html,application/xhtml+xml,*/*;q=0.8")
+	if w.Header().Get("Content-Type") != "text/html; charset=utf-8" || !strings.Contains(w.Body.String(), "<td>a</td>") {
+		t.Errorf("html: %s", w.Body.String())
+	}
+
+	if w := get("text/plain"); w.Body.String() != " Name  Size \n  a     1   \n" {
+		t.Errorf("text: %q", w.Body.String())
+	}
+
+	w = get("application/json;q=1, text/csv;q=0.9")
+	if w.Header().Get("Content-Type") != "text/csv; charset=utf-8" || w.Body.String() != "Name,Size\na,1\n" {
+		t.Errorf("csv: %q", w.Body.String())
+	}
+
+	//the highest q wins, q=0 excludes
+	if w := get("text/html;q=0.1, text/csv"); w.Header().Get("Content-Type") != "text/csv; charset=utf-8" {
+		t.Errorf("q: %q", w.Header().Get("Content-Type"))
+	}
+	if w := get("text/csv;q=0, text/plain;q=0.5, text/tab-separated-values;q=0.5"); w.Header().Get("Content-Type") != "text/plain; charset=utf-8" {
+		t.Errorf("q=0: %q", w.Header().Get("Content-Type"))
+	}
+
+	if w := get(""); w.Header().Get("Vary") != "Accept" || !strings.HasPrefix(w.Body.String(), "<!DOCTYPE html>") {
+		t.Errorf("default: %q", w.Body.String())
+	}
+}
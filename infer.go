@@ -0,0 +1,122 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+//type of column guessed from its cells
+type ColumnType int
+
+const (
+	TypeString ColumnType = iota
+	TypeInt
+	TypeFloat
+	TypeBool
+	TypeTime
+)
+
+//names of column types
+var columnTypeNames = [...]string{"string", "int", "float", "bool", "time"}
+
+func (this ColumnType) String() string {
+	if this < 0 || int(this) >= len(columnTypeNames) {
+		return "ColumnType(" + strconv.Itoa(int(this)) + ")"
+	}
+	return columnTypeNames[this]
+}
+
+//layouts of time cells, such as time.Time's String and RFC3339
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"15:04:05",
+	time.RFC1123,
+	time.RFC1123Z,
+	time.ANSIC,
+}
+
+//texts of bool cells, lower case
+var boolTexts = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true, "on": true, "off": true, "✓": true, "✗": true,
+}
+
+//types of cell
+const (
+	maskInt = 1 << iota
+	maskFloat
+	maskBool
+	maskTime
+	maskAll = maskInt | maskFloat | maskBool | maskTime
+)
+
+//guess types of columns by their data cells, such as for a Schema, sorting compares cells of
+//TypeInt and TypeFloat columns as numbers, a type fits all the non-empty cells, such as 1 and 2.5 are TypeFloat,
+//columns without data are TypeString
+func InferTypes(t *Table) []ColumnType {
+	s := t.state(nil)
+	types := make([]ColumnType, s.colNum)
+	for col := range types {
+		types[col] = s.inferType(col)
+	}
+	return types
+}
+
+//type fitting the non-empty data cells of column
+func (this *state) inferType(col int) ColumnType {
+	mask, seen := maskAll, false
+	for _, line := range this.cells[this.headRows():] {
+		if col >= len(line) || this.isEmptyCell(col, line[col]) {
+			continue
+		}
+		mask &= cellMask(line[col], this.BoolText)
+		seen = true
+		if mask == 0 {
+			break
+		}
+	}
+
+	switch {
+	case !seen:
+	case mask&maskInt != 0:
+		return TypeInt
+	case mask&maskFloat != 0:
+		return TypeFloat
+	case mask&maskBool != 0:
+		return TypeBool
+	case mask&maskTime != 0:
+		return TypeTime
+	}
+	return TypeString
+}
+
+//blank, placeholder or empty text of column
+func (this *state) isEmptyCell(col int, val string) bool {
+	val = strings.TrimSpace(val)
+	return val == "" || val == this.Placeholder || col < len(this.empties) && val == this.empties[col]
+}
+
+//types fitting cell, numbers such as 1,234 and (12.50) of Accounting are allowed
+func cellMask(val string, texts BoolStyle) (mask int) {
+	val = strings.TrimSpace(val)
+	if n, digits, ok := parseNumber(val); ok {
+		mask |= maskFloat
+		if digits == 0 && n == float64(int64(n)) && !strings.ContainsAny(val, ".eEnN") {
+			mask |= maskInt
+		}
+		return mask
+	}
+	if lower := strings.ToLower(val); boolTexts[lower] || texts != (BoolStyle{}) && (val == texts[0] || val == texts[1]) {
+		return maskBool
+	}
+	for _, layout := range timeLayouts {
+		if _, err := time.Parse(layout, val); err == nil {
+			return maskTime
+		}
+	}
+	return 0
+}
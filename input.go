@@ -0,0 +1,158 @@
+package table
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+//format of string to be formatted
+type InputFormat int
+
+const (
+	//rows split by RowSeparator and cells by ColumnSeparator
+	InputText InputFormat = iota
+	//detect json, logfmt, tsv and csv, other strings and custom separators are text
+	InputAuto
+	//array of objects with a column per key, other json values as they are
+	InputJSON
+	//comma separated values, the first record is header
+	InputCSV
+	//tab separated values, the first record is header
+	InputTSV
+	//key=value lines with a column per key
+	InputLogfmt
+)
+
+//format of string, custom separators mean text
+func (this *state) inputFormat(data string) InputFormat {
+	if this.Input != InputAuto {
+		return this.Input
+	}
+	if this.ColumnSeparator != "" || this.RowSeparator != "\n" {
+		return InputText
+	}
+
+	trimmed := strings.TrimSpace(data)
+	if (strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "{")) && json.Valid([]byte(trimmed)) {
+		return InputJSON
+	}
+	lines := this.getLines(data)
+	switch {
+	case isLogfmt(lines):
+		return InputLogfmt
+	case sameCount(lines, "\t"):
+		return InputTSV
+	case sameCount(lines, ",") && !strings.Contains(trimmed, "\""):
+		return InputCSV
+	}
+	return InputText
+}
+
+//parse string by its format, text is parsed when the format does not fit
+func (this *state) parseInput(data string) bool {
+	switch this.inputFormat(data) {
+	case InputJSON:
+		return this.parseJSON(data)
+	case InputCSV:
+		return this.parseCSV(data, ',')
+	case InputTSV:
+		return this.parseCSV(data, '\t')
+	case InputLogfmt:
+		records, err := ReadLogfmt(strings.NewReader(data))
+		if err != nil || len(records) < 2 {
+			return false
+		}
+		this.encodeRecords(records)
+		return true
+	}
+	return false
+}
+
+//encode json value, arrays of objects have a column per key, object keys are sorted
+func (this *state) parseJSON(data string) bool {
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return false
+	}
+
+	switch o := v.(type) {
+	case []interface{}:
+		rows := make([]map[string]interface{}, 0, len(o))
+		for _, item := range o {
+			row, ok := item.(map[string]interface{})
+			if !ok {
+				this.encodeAny(reflect.ValueOf(o))
+				return true
+			}
+			rows = append(rows, row)
+		}
+		this.encodeAny(reflect.ValueOf(rows))
+	case map[string]interface{}:
+		keys := make([]string, 0, len(o))
+		for key := range o {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		this.addRow(this.emptyHeader(2))
+		for _, key := range keys {
+			this.addRow([]string{key, this.encodeCell(reflect.ValueOf(o[key]))})
+		}
+	}
+	return true
+}
+
+//encode records of csv or tsv, empty fields are empty cells
+func (this *state) parseCSV(data string, comma rune) bool {
+	r := csv.NewReader(strings.NewReader(data))
+	r.Comma = comma
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil || len(records) == 0 {
+		return false
+	}
+	this.encodeRecords(records)
+	return true
+}
+
+//every line has key=value pairs only
+func isLogfmt(lines []string) bool {
+	if len(lines) == 0 {
+		return false
+	}
+	for _, line := range lines {
+		keys, _ := parseLogfmt(line)
+		if len(keys) == 0 {
+			return false
+		}
+		for _, key := range keys {
+			if key == "" || !strings.Contains(line, key+"=") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+//two lines or more with the same number of sep at least once
+func sameCount(lines []string, sep string) bool {
+	if len(lines) < 2 {
+		return false
+	}
+	n := strings.Count(lines[0], sep)
+	if n == 0 {
+		return false
+	}
+	for _, line := range lines[1:] {
+		if strings.Count(line, sep) != n {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,39 @@
+package table
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+//compacted or indented json of string or []byte, false when it is not valid json
+func formatJSON(v reflect.Value, indent bool) (str string, ok bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+
+	var data []byte
+	switch {
+	case v.Kind() == reflect.String:
+		data = []byte(v.String())
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		data = v.Bytes()
+	default:
+		return "", false
+	}
+
+	var buf bytes.Buffer
+	var err error
+	if indent {
+		err = json.Indent(&buf, data, "", "  ")
+	} else {
+		err = json.Compact(&buf, data)
+	}
+	if err != nil {
+		return "", false
+	}
+	return buf.String(), true
+}
@@ -0,0 +1,52 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+)
+
+//show the named column's cells by labels of their texts for one call,
+//such as map[string]string{"0": "pending", "1": "running", "2": "done"}
+func WithLabels(name string, labels map[string]string) Option {
+	return func(this *Options) {
+		all := make(map[string]map[string]string, len(this.Labels)+1)
+		for k, v := range this.Labels {
+			all[k] = v
+		}
+		all[name] = labels
+		this.Labels = all
+	}
+}
+
+//show values of type t by labels everywhere, values without label are printed as usual,
+//keys are values of t, such as map[interface{}]string{Pending: "pending"}
+func RegisterLabels(t reflect.Type, labels map[interface{}]string) {
+	RegisterConverter(t, func(v interface{}) string {
+		if label, ok := labels[v]; ok {
+			return label
+		}
+		return fmt.Sprint(v)
+	})
+}
+
+//labels of header names
+func (this *state) addLabels(names []string) {
+	for col, name := range names {
+		if labels, ok := this.Labels[name]; ok {
+			if this.labels == nil {
+				this.labels = make([]map[string]string, len(names))
+			}
+			this.labels[col] = labels
+		}
+	}
+}
+
+//label of cell text
+func (this *state) label(col int, val string) string {
+	if col < len(this.labels) && this.labels[col] != nil {
+		if label, ok := this.labels[col][val]; ok {
+			return label
+		}
+	}
+	return val
+}
@@ -0,0 +1,40 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+)
+
+type jobState int
+
+const (
+	jobPending jobState = iota
+	jobRunning
+	jobDone
+)
+
+func TestLabels(t *testing.T) {
+	type Job struct {
+		Name  string
+		Code  int
+		State jobState
+	}
+	jobs := []Job{{"a", 0, jobPending}, {"b", 2, jobDone}, {"c", 9, 9}}
+
+	RegisterLabels(reflect.TypeOf(jobState(0)), map[interface{}]string{
+		jobPending: "pending",
+		jobRunning: "running",
+		jobDone:    "done",
+	})
+	defer RegisterConverter(reflect.TypeOf(jobState(0)), nil)
+
+	expected := "" +
+		"    Name  Code   State  \n" +
+		" 1   a     ok   pending \n" +
+		" 2   b    fail   done   \n" +
+		" 3   c     9       9    \n"
+	out := Format(jobs, WithTheme(ThemePlain), WithLabels("Code", map[string]string{"0": "ok", "2": "fail"}))
+	if out != expected {
+		t.Errorf("labels:\n%q", out)
+	}
+}
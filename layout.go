@@ -0,0 +1,50 @@
+package table
+
+import "fmt"
+
+//align columns in order by spec for one call, l for left, r for right and c for center,
+//such as "l r r c" or "|l|rrc|", other letters are errors, missing columns keep the theme's alignment,
+//row numbers of lists are not counted
+func WithLayout(spec string) Option {
+	return func(this *Options) {
+		this.Layout = spec
+	}
+}
+
+//alignments of Layout in column order, spaces and bars are skipped, other letters abort the call
+func layoutAligns(spec string) []Align {
+	var aligns []Align
+	for _, r := range spec {
+		switch r {
+		case ' ', '\t', '|':
+			continue
+		case 'l', 'L':
+			aligns = append(aligns, AlignLeft)
+		case 'r', 'R':
+			aligns = append(aligns, AlignRight)
+		case 'c', 'C':
+			aligns = append(aligns, AlignCenter)
+		default:
+			panic(abort{fmt.Errorf("table: unknown letter %q of layout %q", r, spec)})
+		}
+	}
+	return aligns
+}
+
+//set alignments of Layout, Schema alignments are overridden, row numbers of lists are not counted
+func (this *state) applyLayout() {
+	first := 0
+	if this.indexed {
+		first = 1
+	}
+	for i, align := range layoutAligns(this.Layout) {
+		col := first + i
+		if col >= this.colNum {
+			break
+		}
+		if this.aligns == nil {
+			this.aligns = make([]Align, this.colNum)
+		}
+		this.aligns[col] = align
+	}
+}
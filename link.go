@@ -0,0 +1,51 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+//hyperlink cell, shown as OSC 8 terminal hyperlink when Hyperlinks is set
+type Link struct {
+	Text string
+	URL  string
+}
+
+//plain text of link
+func (this Link) String() string {
+	if this.Text == "" || this.Text == this.URL {
+		return this.URL
+	}
+	return this.Text + " (" + this.URL + ")"
+}
+
+var linkType = reflect.TypeOf(Link{})
+
+//OSC 8 hyperlink or plain text
+func (this *state) link(text, url string) string {
+	if url == "" {
+		return text
+	}
+	if !this.Hyperlinks || this.noColor() || this.Sanitize {
+		return Link{Text: text, URL: url}.String()
+	}
+	url = escapeURL(url)
+	if text == "" {
+		text = url
+	}
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+//percent-encode control bytes of url, such as ESC and BEL ending the OSC 8 sequence
+func escapeURL(url string) string {
+	var buf strings.Builder
+	for i := 0; i < len(url); i++ {
+		if c := url[i]; c < 0x20 || c == 0x7f {
+			fmt.Fprintf(&buf, "%%%02X", c)
+		} else {
+			buf.WriteByte(c)
+		}
+	}
+	return buf.String()
+}
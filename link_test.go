@@ -0,0 +1,42 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+//links with and without terminal hyperlinks
+func TestLink(t *testing.T) {
+	type Repo struct {
+		Name string
+		Home string `table:",,link"`
+		Docs Link
+	}
+	repos := []Repo{{"table", "https://github.com/fanzhidongyzby/TableFormat", Link{"docs", "https://pkg.go.dev"}}}
+
+	str := Format(repos)
+	if strings.Contains(str, "\x1b") || !strings.Contains(str, "docs (https://pkg.go.dev)") {
+		t.Errorf("plain fallback expected:\n%s", str)
+	}
+
+	str = Format(repos, func(o *Options) { o.Hyperlinks = true })
+	osc := "\x1b]8;;https://pkg.go.dev\x1b\\docs\x1b]8;;\x1b\\"
+	if !strings.Contains(str, osc) {
+		t.Errorf("hyperlink expected:\n%q", str)
+	}
+
+	//borders stay aligned
+	lines := strings.Split(strings.TrimSpace(str), "\n")
+	s := newState(Defaults(), nil)
+	for _, line := range lines {
+		if s.width(line) != s.width(lines[0]) {
+			t.Errorf("misaligned line %q", line)
+		}
+	}
+
+	//control bytes cannot end the sequence
+	str = Format([]Link{{"x", "https://a\x1b]8;;\x07b"}}, func(o *Options) { o.Hyperlinks = true })
+	if !strings.Contains(str, "\x1b]8;;https://a%1B]8;;%07b\x1b\\x\x1b]8;;\x1b\\") {
+		t.Errorf("escaped url:\n%q", str)
+	}
+}
@@ -0,0 +1,121 @@
+package table
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//read logfmt lines such as `level=info msg="user created" id=7`, the first record is the union
+//of keys in order of appearance, missing values are empty, blank lines are skipped
+func ReadLogfmt(r io.Reader) (records [][]string, err error) {
+	index := map[string]int{}
+	header := []string{}
+	var rows [][]string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		keys, vals := parseLogfmt(scanner.Text())
+		if len(keys) == 0 {
+			continue
+		}
+		row := make([]string, len(header))
+		for i, key := range keys {
+			col, ok := index[key]
+			if !ok {
+				col = len(header)
+				index[key] = col
+				header = append(header, key)
+				row = append(row, "")
+			}
+			row[col] = vals[i]
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	//earlier rows are shorter
+	records = append([][]string{header}, rows...)
+	for i, row := range records {
+		if len(row) < len(header) {
+			records[i] = append(row, make([]string, len(header)-len(row))...)
+		}
+	}
+	return records, nil
+}
+
+//format logfmt lines as table with a column per key
+func FormatLogfmt(r io.Reader, opts ...Option) (string, error) {
+	records, err := ReadLogfmt(r)
+	if err != nil {
+		return "", err
+	}
+	s := newState(Defaults(), opts)
+	if err := catch(func() { s.encodeRecords(records) }); err != nil {
+		return "", err
+	}
+	return s.format(), nil
+}
+
+//split records, the first is header
+func (this *state) encodeRecords(records [][]string) {
+	this.grow(len(records))
+	for _, record := range records {
+		this.addRow(record)
+	}
+}
+
+//keys and values of logfmt line, keys without value have empty value
+func parseLogfmt(line string) (keys, vals []string) {
+	for i := 0; i < len(line); {
+		//key
+		if line[i] == ' ' || line[i] == '\t' {
+			i++
+			continue
+		}
+		start := i
+		for i < len(line) && line[i] != '=' && line[i] != ' ' && line[i] != '\t' {
+			i++
+		}
+		key := line[start:i]
+		if i >= len(line) || line[i] != '=' {
+			keys, vals = append(keys, key), append(vals, "")
+			continue
+		}
+		i++
+
+		//quoted or bare value
+		start = i
+		if i < len(line) && line[i] == '"' {
+			for i++; i < len(line) && line[i] != '"'; i++ {
+				if line[i] == '\\' {
+					i++
+				}
+			}
+			if i < len(line) {
+				i++
+			}
+		} else {
+			for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+				i++
+			}
+		}
+		keys, vals = append(keys, key), append(vals, unquote(line[start:i]))
+	}
+	return keys, vals
+}
+
+//value of quoted string, broken quotes are trimmed
+func unquote(val string) string {
+	if !strings.HasPrefix(val, `"`) {
+		return val
+	}
+	if str, err := strconv.Unquote(val); err == nil {
+		return str
+	}
+	return strings.Trim(val, `"`)
+}
@@ -0,0 +1,52 @@
+package table
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLogfmt(t *testing.T) {
+	logs := `level=info msg="user created" id=7
+level=warn msg="slow \"query\"" took=1.2s
+
+level=error msg=failed retry`
+
+	str, err := FormatLogfmt(strings.NewReader(logs), WithTheme(ThemePlain))
+	expected := "" +
+		" level      msg       id  took  retry \n" +
+		" info   user created  7               \n" +
+		" warn   slow \"query\"      1.2s        \n" +
+		" error     failed                     \n"
+	if err != nil || str != expected {
+		t.Errorf("logfmt %v:\n%q", err, str)
+	}
+
+	//schema errors are returned
+	schema := WithSchema(Schema{{Name: "id", Type: TypeInt}})
+	if _, err := FormatLogfmt(strings.NewReader("id=x"), schema); err == nil || !strings.Contains(err.Error(), `"x"`) {
+		t.Errorf("schema error: %v", err)
+	}
+}
+
+//named groups as columns
+func TestFormatRegex(t *testing.T) {
+	logs := "2024-01-02 INFO started\nnoise\n2024-01-03 WARN disk almost full\n"
+	re := regexp.MustCompile(`^(?P<date>\S+) (?P<level>[A-Z]+) (\w+)(?P<rest>.*)$`)
+
+	str, err := FormatRegex(strings.NewReader(logs), re, WithTheme(ThemePlain))
+	expected := "" +
+		"    date     level      rest     \n" +
+		" 2024-01-02  INFO                \n" +
+		" 2024-01-03  WARN    almost full \n"
+	if err != nil || str != expected {
+		t.Errorf("regex %v:\n%q", err, str)
+	}
+
+	//schema errors are returned
+	re = regexp.MustCompile(`(?P<s>\w) (?P<n>\w)`)
+	schema := WithSchema(Schema{{Name: "n", Type: TypeInt}})
+	if _, err := FormatRegex(strings.NewReader("a 1\nb x"), re, schema); err == nil || !strings.Contains(err.Error(), `"x"`) {
+		t.Errorf("schema error: %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package table
+
+import (
+	"reflect"
+	"strconv"
+)
+
+//numeric matrix with labeled rows and columns, such as correlation matrices and benchmark grids,
+//numbers have prec digits after the point rounded by Rounding, -1 means the shortest text,
+//nil rowLabels means no label column and nil colLabels means no header
+func FormatMatrix(data [][]float64, rowLabels, colLabels []string, prec int, opts ...Option) string {
+	return NewFormatter(opts...).FormatMatrix(data, rowLabels, colLabels, prec)
+}
+
+//matrix with the formatter's options
+func (this *Formatter) FormatMatrix(data [][]float64, rowLabels, colLabels []string, prec int, opts ...Option) string {
+	s := newState(this.options, opts)
+
+	//label column
+	var labels []string
+	if rowLabels != nil {
+		labels = []string{s.Placeholder}
+	}
+	colNum := len(colLabels)
+	for _, row := range data {
+		if len(row) > colNum {
+			colNum = len(row)
+		}
+	}
+
+	//errors such as *SchemaError are shown like Format
+	err := catch(func() {
+		s.encodeFloats(data, labels, rowLabels, colLabels, colNum, prec)
+	})
+	if err != nil {
+		s.showError(err)
+		return s.format()
+	}
+
+	//numbers are right aligned unless Schema or Layout aligns them
+	aligns := make([]Align, s.colNum)
+	copy(aligns, s.aligns)
+	for col := range aligns {
+		if aligns[col] != AlignDefault {
+			continue
+		}
+		if col < len(labels) {
+			aligns[col] = AlignLeft
+		} else {
+			aligns[col] = AlignRight
+		}
+	}
+	s.aligns = aligns
+	return s.format()
+}
+
+//add header and rows of matrix, short rows are padded with empty cells
+func (this *state) encodeFloats(data [][]float64, labels, rowLabels, colLabels []string, colNum, prec int) {
+	if colLabels != nil {
+		this.addRow(concat(labels, cellsOf(colLabels, colNum)))
+	} else {
+		this.addRow(this.emptyHeader(len(labels) + colNum))
+	}
+	for i, row := range data {
+		line := make([]string, 0, len(labels)+colNum)
+		if rowLabels != nil {
+			line = append(line, cellOf(rowLabels, i))
+		}
+		for _, n := range row {
+			str, ok := this.formatFloat(reflect.ValueOf(n), prec, NotationAuto)
+			if !ok {
+				str = strconv.FormatFloat(n, 'g', -1, 64)
+			}
+			line = append(line, str)
+		}
+		this.addRow(append(line, make([]string, colNum-len(row))...))
+	}
+}
@@ -0,0 +1,38 @@
+package table
+
+import (
+	"testing"
+)
+
+//labeled and unlabeled matrices
+func TestFormatMatrix(t *testing.T) {
+	data := [][]float64{{1, 0.8125}, {0.8125, 1}}
+	plain := WithTheme(ThemePlain)
+
+	expected := "" +
+		"       cpu   mem \n" +
+		" cpu  1.00  0.81 \n" +
+		" mem  0.81  1.00 \n"
+	if str := FormatMatrix(data, []string{"cpu", "mem"}, []string{"cpu", "mem"}, 2, plain); str != expected {
+		t.Errorf("labeled:\n%q", str)
+	}
+
+	if str := FormatMatrix(data, nil, nil, -1, plain); str != "      1  0.8125 \n 0.8125       1 \n" {
+		t.Errorf("unlabeled:\n%q", str)
+	}
+
+	//Layout overrides the default alignments, missing columns keep them
+	expected = "" +
+		"       cpu    mem \n" +
+		"  cpu  1.00  0.81 \n" +
+		" memo  0.81  1.00 \n"
+	if str := FormatMatrix(data, []string{"cpu", "memo"}, []string{"cpu", "mem"}, 2, plain, WithLayout("r l")); str != expected {
+		t.Errorf("layout:\n%q", str)
+	}
+
+	//short rows are padded in Strict mode
+	strict := func(o *Options) { o.Strict = true }
+	if str := FormatMatrix([][]float64{{1, 2}, {1}}, nil, nil, -1, plain, strict); str != " 1  2 \n 1    \n" {
+		t.Errorf("ragged:\n%q", str)
+	}
+}
@@ -0,0 +1,228 @@
+package table
+
+import (
+	"context"
+	"errors"
+)
+
+//row or column index of Table methods is out of range
+var ErrRange = errors.New("table: index out of range")
+
+/*
+Encoded table
+
+Description: Encode converts any type to a table like Format,
+	but returns the cells instead of the formatted string,
+	so that they can be inspected and changed before formatting.
+	Cells are the shown texts, options such as renaming, labels
+	and empty texts are already applied. For example:
+
+	t, err := table.Encode(users)
+	for _, row := range t.Rows {
+		row[2] = strings.ToUpper(row[2])
+	}
+	fmt.Print(t.Format())
+*/
+type Table struct {
+	//header names, nil when the table has no header
+	Headers []string
+	//units under header, nil when there is none
+	Units []string
+	//data rows
+	Rows [][]string
+	//widest cell of every column without padding, cells added later widen it,
+	//Format measures cells again
+	ColumnWidths []int
+
+	//options of encoding and state of columns
+	options Options
+	grid    grid
+}
+
+//encode obj into a table with the current global options and opts, Strict errors are returned
+func Encode(obj interface{}, opts ...Option) (*Table, error) {
+	return newState(Defaults(), opts).runTable(obj)
+}
+
+//table of cells with the current global options and opts, such as csv records,
+//cells are shown as they are, nil headers means no header
+func NewTable(headers []string, rows [][]string, opts ...Option) *Table {
+	return newTable(newState(Defaults(), opts).Options, headers, nil, rows)
+}
+
+//encode obj into a table with the formatter's options
+func (this *Formatter) Encode(obj interface{}, opts ...Option) (*Table, error) {
+	return newState(this.options, opts).runTable(obj)
+}
+
+//format table with its options, opts only affect this call
+func (this *Table) Format(opts ...Option) string {
+	return this.state(opts).format()
+}
+
+//encode object into table
+func (this *state) runTable(obj interface{}) (t *Table, err error) {
+	//errors are returned instead of shown
+	this.ctx = context.Background()
+	defer func() {
+		if r := recover(); r != nil {
+			a, ok := r.(abort)
+			if !ok {
+				panic(r)
+			}
+			t, err = nil, a.err
+		}
+	}()
+
+	this.encode(obj)
+	this.ctx = nil
+	return this.table(), nil
+}
+
+//table of encoded grid
+func (this *state) table() *Table {
+	//nothing is encoded from empty objects
+	head := this.headRows()
+	if head > len(this.cells) {
+		head = len(this.cells)
+	}
+	t := &Table{
+		Rows:         this.cells[head:],
+		ColumnWidths: append([]int{}, this.widths...),
+		options:      this.Options,
+		grid:         this.grid,
+	}
+	if !this.headless && len(this.cells) != 0 {
+		t.Headers = this.cells[0]
+	}
+	if this.unitRow {
+		t.Units = this.cells[1]
+	}
+	t.grid.cells, t.grid.widths = nil, nil
+	return t
+}
+
+//table of cells with options, columns are named by headers and measured
+func newTable(options Options, headers, units []string, rows [][]string) *Table {
+	t := &Table{Headers: headers, Units: units, Rows: rows, options: options}
+	t.grid.names = headers
+	t.ColumnWidths = t.state(nil).widths
+	return t
+}
+
+//state of table, rows are filled to the same length and widths are measured again
+func (this *Table) state(opts []Option) *state {
+	s := newState(this.options, opts)
+	s.grid = this.grid
+	s.headless = this.Headers == nil
+	s.unitRow = this.Headers != nil && this.Units != nil
+
+	//head rows and data rows
+	s.cells = make([][]string, 0, len(this.Rows)+2)
+	if this.Headers != nil {
+		s.cells = append(s.cells, this.Headers)
+	}
+	if s.unitRow {
+		s.cells = append(s.cells, this.Units)
+	}
+	s.cells = append(s.cells, this.Rows...)
+
+	s.colNum = 0
+	for _, line := range s.cells {
+		if len(line) > s.colNum {
+			s.colNum = len(line)
+		}
+	}
+	s.widths = make([]int, s.colNum)
+	for row, line := range s.cells {
+		if len(line) < s.colNum {
+			line = append(append([]string{}, line...), make([]string, s.colNum-len(line))...)
+			s.cells[row] = line
+		}
+		for col, val := range line {
+			if size := s.cellWidth(val); size > s.widths[col] {
+				s.widths[col] = size
+			}
+		}
+	}
+	return s
+}
+
+//index of column by header name, -1 when there is none
+func (this *Table) ColumnIndex(name string) int {
+	for col, header := range this.Headers {
+		if header == name {
+			return col
+		}
+	}
+	return -1
+}
+
+//set cell of data row, space characters are replaced as encoding does
+func (this *Table) SetCell(row, col int, val string) error {
+	if row < 0 || row >= len(this.Rows) || col < 0 || col >= len(this.Rows[row]) {
+		return ErrRange
+	}
+	this.Rows[row][col] = this.cell(val)
+	this.widen(col, this.Rows[row][col])
+	return nil
+}
+
+//insert data row before index, len(Rows) appends it, cells are cut or filled to the columns
+func (this *Table) InsertRow(index int, cells []string) error {
+	if index < 0 || index > len(this.Rows) {
+		return ErrRange
+	}
+
+	line := make([]string, len(this.ColumnWidths))
+	for col := range line {
+		if col < len(cells) {
+			line[col] = this.cell(cells[col])
+			this.widen(col, line[col])
+		}
+	}
+
+	this.Rows = append(this.Rows, nil)
+	copy(this.Rows[index+1:], this.Rows[index:])
+	this.Rows[index] = line
+	return nil
+}
+
+//delete data row
+func (this *Table) DeleteRow(index int) error {
+	if index < 0 || index >= len(this.Rows) {
+		return ErrRange
+	}
+	this.Rows = append(this.Rows[:index:index], this.Rows[index+1:]...)
+	return nil
+}
+
+//delete column of header and all rows, such as a column of secrets
+func (this *Table) DeleteColumn(col int) error {
+	if col < 0 || col >= len(this.ColumnWidths) {
+		return ErrRange
+	}
+
+	this.Headers = deleteString(this.Headers, col)
+	this.Units = deleteString(this.Units, col)
+	for row, line := range this.Rows {
+		this.Rows[row] = deleteString(line, col)
+	}
+	this.ColumnWidths = append(this.ColumnWidths[:col:col], this.ColumnWidths[col+1:]...)
+	this.grid.deleteColumn(col)
+	return nil
+}
+
+//cell text of value
+func (this *Table) cell(val string) string {
+	s := state{Options: this.options}
+	return s.handleSpace(val)
+}
+
+//widen column for cell
+func (this *Table) widen(col int, val string) {
+	s := state{Options: this.options}
+	if size := s.cellWidth(val); col < len(this.ColumnWidths) && size > this.ColumnWidths[col] {
+		this.ColumnWidths[col] = size
+	}
+}
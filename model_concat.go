@@ -0,0 +1,64 @@
+package table
+
+//union of data rows of tables with columns matched by header name in order of appearance, such as results of
+//several sources, missing cells are Placeholder, columns of tables without header are matched by index,
+//the result has the options of the first table
+func Concat(tables ...*Table) *Table {
+	if len(tables) == 0 {
+		return newTable(Defaults(), nil, nil, nil)
+	}
+
+	//columns by name
+	var headers, units []string
+	index := map[string]int{}
+	hasUnits := false
+	for _, t := range tables {
+		hasUnits = hasUnits || t.Units != nil
+		for col, name := range t.Headers {
+			if _, ok := index[name]; !ok {
+				index[name] = len(headers)
+				headers = append(headers, name)
+				units = append(units, "")
+			}
+			if unit := cellOf(t.Units, col); unit != "" && units[index[name]] == "" {
+				units[index[name]] = unit
+			}
+		}
+	}
+	colNum := len(headers)
+	for _, t := range tables {
+		for _, row := range t.Rows {
+			if t.Headers == nil && len(row) > colNum {
+				colNum = len(row)
+			}
+		}
+	}
+	if hasUnits {
+		units = cellsOf(units, colNum)
+	} else {
+		units = nil
+	}
+
+	placeholder := tables[0].options.Placeholder
+	var rows [][]string
+	for _, t := range tables {
+		for _, row := range t.Rows {
+			line := make([]string, colNum)
+			for col := range line {
+				line[col] = placeholder
+			}
+			for col, val := range row {
+				if t.Headers == nil {
+					line[col] = val
+				} else if col < len(t.Headers) {
+					line[index[t.Headers[col]]] = val
+				}
+			}
+			rows = append(rows, line)
+		}
+	}
+	if headers != nil {
+		headers = cellsOf(headers, colNum)
+	}
+	return newTable(tables[0].options, headers, units, rows)
+}
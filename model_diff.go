@@ -0,0 +1,97 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+)
+
+//lines of DiffString, more differences are counted
+const maxDiffs = 20
+
+//tables have the same headers, units and rows, options and column widths are not compared
+func Equal(a, b *Table) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return equalStrings(a.Headers, b.Headers) && equalStrings(a.Units, b.Units) && equalRows(a.Rows, b.Rows)
+}
+
+//differences of b from a line by line, such as `row 2 "Age": "30" != "31"`, empty string means Equal,
+//rows are counted from 1
+func DiffString(a, b *Table) string {
+	if a == nil || b == nil {
+		if a == b {
+			return ""
+		}
+		return fmt.Sprintf("table: %v != %v\n", a != nil, b != nil)
+	}
+
+	var diffs []string
+	if !equalStrings(a.Headers, b.Headers) {
+		diffs = append(diffs, fmt.Sprintf("headers: %q != %q", a.Headers, b.Headers))
+	}
+	if !equalStrings(a.Units, b.Units) {
+		diffs = append(diffs, fmt.Sprintf("units: %q != %q", a.Units, b.Units))
+	}
+	if len(a.Rows) != len(b.Rows) {
+		diffs = append(diffs, fmt.Sprintf("rows: %d != %d", len(a.Rows), len(b.Rows)))
+	}
+	for row := 0; row < len(a.Rows) || row < len(b.Rows); row++ {
+		switch {
+		case row >= len(b.Rows):
+			diffs = append(diffs, fmt.Sprintf("row %d: %q != missing", row+1, a.Rows[row]))
+		case row >= len(a.Rows):
+			diffs = append(diffs, fmt.Sprintf("row %d: missing != %q", row+1, b.Rows[row]))
+		case len(a.Rows[row]) != len(b.Rows[row]):
+			diffs = append(diffs, fmt.Sprintf("row %d: %q != %q", row+1, a.Rows[row], b.Rows[row]))
+		default:
+			for col, val := range a.Rows[row] {
+				if val != b.Rows[row][col] {
+					diffs = append(diffs, fmt.Sprintf("row %d %s: %q != %q", row+1, a.columnName(col), val, b.Rows[row][col]))
+				}
+			}
+		}
+	}
+
+	if len(diffs) == 0 {
+		return ""
+	}
+	if len(diffs) > maxDiffs {
+		diffs = append(diffs[:maxDiffs], fmt.Sprintf("... %d more", len(diffs)-maxDiffs))
+	}
+	return strings.Join(diffs, "\n") + "\n"
+}
+
+//quoted header name of column, or its index when the table has no such header
+func (this *Table) columnName(col int) string {
+	if col < len(this.Headers) && this.Headers[col] != "" {
+		return fmt.Sprintf("%q", this.Headers[col])
+	}
+	return fmt.Sprintf("col %d", col)
+}
+
+//same length and strings, nil equals empty
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+//same rows of cells
+func equalRows(a, b [][]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !equalStrings(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+package table
+
+import (
+	"strings"
+)
+
+//data rows of table grouped by the cells of columns
+type Grouping struct {
+	table *Table
+	cols  []int
+}
+
+//group data rows by the named columns, unknown names are ignored, such as
+//t.GroupBy("Region").Aggregate(table.Sum("Sales"), table.Count())
+func (this *Table) GroupBy(names ...string) *Grouping {
+	g := &Grouping{table: this}
+	for _, name := range names {
+		if col := this.ColumnIndex(name); col >= 0 {
+			g.cols = append(g.cols, col)
+		}
+	}
+	return g
+}
+
+//aggregate function of the non-empty cells of a column in a group
+type Aggregation struct {
+	//header of result column
+	Name string
+	//column of aggregated cells, empty means a cell per row such as Count
+	Column string
+	Func   func(cells []string) string
+}
+
+//aggregation with another header
+func (this Aggregation) As(name string) Aggregation {
+	this.Name = name
+	return this
+}
+
+//sum of numbers of column with the most digits of them, units such as $5 are allowed
+func Sum(column string) Aggregation {
+	return Aggregation{Name: "sum(" + column + ")", Column: column, Func: sumFooter}
+}
+
+//average of numbers of column
+func Avg(column string) Aggregation {
+	return Aggregation{Name: "avg(" + column + ")", Column: column, Func: avgFooter}
+}
+
+//smallest number of column as shown
+func Min(column string) Aggregation {
+	return Aggregation{Name: "min(" + column + ")", Column: column, Func: func(cells []string) string { return extremeCell(cells, -1) }}
+}
+
+//largest number of column as shown
+func Max(column string) Aggregation {
+	return Aggregation{Name: "max(" + column + ")", Column: column, Func: func(cells []string) string { return extremeCell(cells, 1) }}
+}
+
+//number of rows
+func Count() Aggregation {
+	return Aggregation{Name: "count", Func: countFooter}
+}
+
+//table of a row per group in order of their first rows, group columns are followed by aggregations,
+//cells of unknown columns are empty
+func (this *Grouping) Aggregate(aggs ...Aggregation) *Table {
+	t := this.table
+	var keys []string
+	groups := map[string][][]string{}
+	for _, row := range t.Rows {
+		cells := make([]string, len(this.cols))
+		for i, col := range this.cols {
+			if col < len(row) {
+				cells[i] = row[col]
+			}
+		}
+		key := strings.Join(cells, "\x00")
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], row)
+	}
+
+	var headers []string
+	if t.Headers != nil {
+		for _, col := range this.cols {
+			headers = append(headers, t.Headers[col])
+		}
+		for _, agg := range aggs {
+			headers = append(headers, agg.Name)
+		}
+	}
+
+	rows := make([][]string, len(keys))
+	for i, key := range keys {
+		group := groups[key]
+		line := make([]string, 0, len(this.cols)+len(aggs))
+		for _, col := range this.cols {
+			line = append(line, cellOf(group[0], col))
+		}
+		for _, agg := range aggs {
+			line = append(line, agg.Func(aggCells(t, group, agg.Column)))
+		}
+		rows[i] = line
+	}
+	return newTable(t.options, headers, nil, rows)
+}
+
+//non-empty cells of column in rows, a cell per row when column is empty
+func aggCells(t *Table, rows [][]string, column string) []string {
+	if column == "" {
+		return make([]string, len(rows))
+	}
+	col := t.ColumnIndex(column)
+	if col < 0 {
+		return nil
+	}
+	var cells []string
+	for _, row := range rows {
+		if val := cellOf(row, col); val != "" {
+			cells = append(cells, val)
+		}
+	}
+	return cells
+}
+
+//cell of row, empty when the row is short
+func cellOf(row []string, col int) string {
+	if col < len(row) {
+		return row[col]
+	}
+	return ""
+}
+
+//cell of the largest number for sign 1 or the smallest for -1, other cells are skipped
+func extremeCell(cells []string, sign float64) string {
+	best, max := "", 0.0
+	for _, val := range cells {
+		n, _, ok := parseNumber(strings.TrimFunc(val, isUnit))
+		if ok && (best == "" || sign*n > max) {
+			best, max = val, sign*n
+		}
+	}
+	return best
+}
@@ -0,0 +1,71 @@
+package table
+
+import (
+	"errors"
+)
+
+//column of Table functions is not in the header
+var ErrColumn = errors.New("table: no such column")
+
+//kind of Join
+type JoinKind int
+
+const (
+	//rows with a match in both tables
+	JoinInner JoinKind = iota
+	//all rows of the left table, cells of missing matches are empty
+	JoinLeft
+)
+
+//join data rows of a and b with equal cells of column key, such as pods and their metrics,
+//columns of b follow columns of a without its key, rows of several matches repeat, the result has the options of a
+func Join(a, b *Table, key string, kind JoinKind) (*Table, error) {
+	ka, kb := a.ColumnIndex(key), b.ColumnIndex(key)
+	if ka < 0 || kb < 0 {
+		return nil, ErrColumn
+	}
+
+	//rows of b by key
+	matches := map[string][][]string{}
+	for _, row := range b.Rows {
+		k := cellOf(row, kb)
+		matches[k] = append(matches[k], row)
+	}
+	others := func(row []string) []string {
+		line := make([]string, 0, len(b.Headers)-1)
+		for col := range b.Headers {
+			if col != kb {
+				line = append(line, cellOf(row, col))
+			}
+		}
+		return line
+	}
+
+	var rows [][]string
+	for _, row := range a.Rows {
+		left := cellsOf(row, len(a.Headers))
+		found := matches[cellOf(row, ka)]
+		if len(found) == 0 && kind == JoinLeft {
+			rows = append(rows, concat(left, make([]string, len(b.Headers)-1)))
+		}
+		for _, match := range found {
+			rows = append(rows, concat(left, others(match)))
+		}
+	}
+
+	headers := concat(a.Headers, others(b.Headers))
+	var units []string
+	if a.Units != nil || b.Units != nil {
+		units = concat(cellsOf(a.Units, len(a.Headers)), others(b.Units))
+	}
+	return newTable(a.options, headers, units, rows), nil
+}
+
+//n cells of row, empty when the row is short
+func cellsOf(row []string, n int) []string {
+	cells := make([]string, n)
+	for col := range cells {
+		cells[col] = cellOf(row, col)
+	}
+	return cells
+}
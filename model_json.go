@@ -0,0 +1,37 @@
+package table
+
+import (
+	"encoding/json"
+)
+
+//json form of table, options are not kept
+type tableJSON struct {
+	Headers []string   `json:"headers,omitempty"`
+	Units   []string   `json:"units,omitempty"`
+	Rows    [][]string `json:"rows"`
+}
+
+//headers, units and rows as json, Table is a json.Marshaler
+func (this *Table) MarshalJSON() ([]byte, error) {
+	rows := this.Rows
+	if rows == nil {
+		rows = [][]string{}
+	}
+	return json.Marshal(tableJSON{Headers: this.Headers, Units: this.Units, Rows: rows})
+}
+
+//read json of MarshalJSON, the table gets the current global options, pass options to Format for other styles
+func (this *Table) UnmarshalJSON(data []byte) error {
+	var t tableJSON
+	if err := json.Unmarshal(data, &t); err != nil {
+		return err
+	}
+
+	*this = *newTable(Defaults(), t.Headers, t.Units, t.Rows)
+	return nil
+}
+
+//text table of theme, Table is an encoding.TextMarshaler
+func (this *Table) MarshalText() ([]byte, error) {
+	return []byte(this.Format()), nil
+}
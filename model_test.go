@@ -0,0 +1,310 @@
+package table
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncode(t *testing.T) {
+	type User struct {
+		Name  string
+		Email string
+	}
+	users := []User{{"ann", "ann@example.com"}, {"bob", "bob@example.com"}}
+
+	tb, err := Encode(users, WithTheme(ThemePlain))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(tb.Headers, ",") != ",Name,Email" || len(tb.Rows) != 2 || tb.ColumnWidths[2] != 15 {
+		t.Errorf("table: %#v", tb)
+	}
+	if tb.Format() != Format(users, WithTheme(ThemePlain)) {
+		t.Errorf("format:\n%s", tb.Format())
+	}
+
+	//changed cells and short rows
+	tb.Rows[0][2] = "hidden"
+	tb.Rows[1] = tb.Rows[1][:2]
+	expected := "" +
+		"    Name  Email  \n" +
+		" 1  ann   hidden \n" +
+		" 2  bob          \n"
+	if str := tb.Format(); str != expected {
+		t.Errorf("changed:\n%q", str)
+	}
+
+	//strict errors
+	if _, err := Encode("a b\n1", func(o *Options) { o.Strict = true }); err == nil {
+		t.Errorf("row error expected")
+	}
+}
+
+//cells of records are not parsed
+func TestNewTable(t *testing.T) {
+	tb := NewTable([]string{"a", "b"}, [][]string{{"_", "x y"}, {"", "z"}}, WithTheme(ThemePlain))
+	if str := tb.Format(); str != " a   b  \n _  x y \n     z  \n" {
+		t.Errorf("records:\n%q", str)
+	}
+	if tb.ColumnWidths[1] != 3 {
+		t.Errorf("widths: %v", tb.ColumnWidths)
+	}
+}
+
+func TestTableChanges(t *testing.T) {
+	type Account struct {
+		Name     string
+		Password string
+		Role     string
+	}
+	tb, _ := Encode([]Account{{"ann", "secret", "admin"}}, WithTheme(ThemePlain))
+
+	if err := tb.DeleteColumn(tb.ColumnIndex("Password")); err != nil {
+		t.Fatal(err)
+	}
+	tb.InsertRow(1, []string{"2", "bob\tsmith", "user", "extra"})
+	tb.SetCell(0, 2, "root")
+	if tb.DeleteRow(5) != ErrRange || tb.SetCell(0, 3, "x") != ErrRange || tb.DeleteColumn(-1) != ErrRange {
+		t.Errorf("range errors expected")
+	}
+
+	expected := "" +
+		"      Name     Role \n" +
+		" 1     ann     root \n" +
+		" 2  bob smith  user \n"
+	if str := tb.Format(); str != expected {
+		t.Errorf("changed:\n%q", str)
+	}
+}
+
+//renderers of other formats
+func TestRenderer(t *testing.T) {
+	tsv := RendererFunc(func(tb *Table, w io.Writer) error {
+		for _, line := range append([][]string{tb.Headers}, tb.Rows...) {
+			fmt.Fprintln(w, strings.Join(line, "\t"))
+		}
+		return nil
+	})
+	RegisterRenderer("tsv", tsv)
+	defer RegisterRenderer("tsv", nil)
+
+	data := "a b\n1 2"
+	r, ok := RendererOf("tsv")
+	if !ok {
+		t.Fatal("tsv is not registered")
+	}
+	if str := Format(data, WithRenderer(r)); str != "a\tb\n1\t2\n" {
+		t.Errorf("tsv:\n%q", str)
+	}
+	if Format(data, WithRenderer(TextRenderer)) != Format(data) {
+		t.Errorf("text:\n%s", Format(data, WithRenderer(TextRenderer)))
+	}
+	if r, _ := RendererOf("html"); Format(data, WithRenderer(r)) != FormatHTML(data) {
+		t.Errorf("html:\n%s", Format(data, WithRenderer(r)))
+	}
+
+	fail := RendererFunc(func(tb *Table, w io.Writer) error { return ErrRange })
+	if str := Format(data, WithRenderer(fail)); str != ErrRange.Error()+"\n" {
+		t.Errorf("error:\n%q", str)
+	}
+}
+
+//tables as writers and strings
+func TestWriteTo(t *testing.T) {
+	tb, _ := Encode("a b\n1 2")
+	var buf bytes.Buffer
+	var w io.WriterTo = tb
+	n, err := w.WriteTo(&buf)
+	if err != nil || buf.String() != Format("a b\n1 2") || n != int64(buf.Len()) {
+		t.Errorf("write %d %v:\n%s", n, err, buf.String())
+	}
+	if fmt.Sprint(tb) != buf.String() {
+		t.Errorf("string:\n%s", tb)
+	}
+
+	tb, _ = Encode("a b\n1 2", WithRenderer(HTMLRenderer))
+	buf.Reset()
+	tb.WriteTo(&buf)
+	if buf.String() != FormatHTML("a b\n1 2") {
+		t.Errorf("html:\n%s", buf.String())
+	}
+}
+
+//tables through json
+func TestTableJSON(t *testing.T) {
+	type Item struct {
+		Name  string
+		Price int `table:",,unit=$"`
+	}
+	tb, _ := Encode([]Item{{"pen", 2}})
+
+	data, err := json.Marshal(tb)
+	if err != nil || string(data) != `{"headers":["","Name","Price"],"units":["","","$"],"rows":[["1","pen","2"]]}` {
+		t.Fatalf("json %v: %s", err, data)
+	}
+
+	var back Table
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back.Format() != tb.Format() || back.ColumnWidths[1] != 4 {
+		t.Errorf("unmarshaled:\n%s", back.Format())
+	}
+	if back.Format(WithTheme(ThemeMarkdown)) != Format([]Item{{"pen", 2}}, WithTheme(ThemeMarkdown)) {
+		t.Errorf("markdown:\n%s", back.Format(WithTheme(ThemeMarkdown)))
+	}
+
+	text, _ := tb.MarshalText()
+	if string(text) != tb.String() {
+		t.Errorf("text:\n%s", text)
+	}
+}
+
+func TestDiffString(t *testing.T) {
+	type User struct {
+		Name string
+		Age  int
+	}
+	a, _ := Encode([]User{{"ann", 30}, {"bob", 4}})
+	b, _ := Encode([]User{{"ann", 31}, {"bob", 4}, {"cid", 7}})
+	if !Equal(a, a) || Equal(a, b) || Equal(a, nil) || !Equal(nil, nil) {
+		t.Errorf("equal")
+	}
+	if diff := DiffString(a, a); diff != "" {
+		t.Errorf("same: %s", diff)
+	}
+
+	expected := "" +
+		"rows: 2 != 3\n" +
+		"row 1 \"Age\": \"30\" != \"31\"\n" +
+		"row 3: missing != [\"3\" \"cid\" \"7\"]\n"
+	if diff := DiffString(a, b); diff != expected {
+		t.Errorf("diff:\n%s", diff)
+	}
+
+	b.Headers[1] = "User"
+	if diff := DiffString(a, b); !strings.HasPrefix(diff, "headers: [\"\" \"Name\" \"Age\"] != [\"\" \"User\" \"Age\"]\n") {
+		t.Errorf("headers:\n%s", diff)
+	}
+}
+
+func TestCSVRenderer(t *testing.T) {
+	type Row struct {
+		Name string
+		Note string
+	}
+	rows := []Row{{"=HYPERLINK(\"x\")", "a,b"}, {"@SUM(A1)", "-1.5"}}
+	if str := Format(rows, WithRenderer(CSVRenderer)); str != ",Name,Note\n1,\"=HYPERLINK(\"\"x\"\")\",\"a,b\"\n2,@SUM(A1),-1.5\n" {
+		t.Errorf("csv:\n%q", str)
+	}
+	escape := func(o *Options) { o.EscapeFormulas = true }
+	if str := Format(rows, WithRenderer(TSVRenderer), escape); str != "\tName\tNote\n1\t\"'=HYPERLINK(\"\"x\"\")\"\ta,b\n2\t'@SUM(A1)\t-1.5\n" {
+		t.Errorf("tsv:\n%q", str)
+	}
+}
+
+func TestInferTypes(t *testing.T) {
+	type Row struct {
+		ID    int
+		Price float64
+		Ok    bool
+		Day   time.Time
+		Note  string
+		Empty *int
+	}
+	day := time.Date(2020, 5, 17, 10, 0, 0, 0, time.UTC)
+	tb, _ := Encode([]Row{{1, 2, true, day, "a", nil}, {2, 2.5, false, day, "3", nil}}, func(o *Options) { o.Accounting = true })
+	tb.Rows[1][1] = "(1,024)"
+	types := fmt.Sprint(InferTypes(tb))
+	if types != "[int int float bool time string string]" {
+		t.Errorf("types: %s", types)
+	}
+}
+
+//rollup of groups
+func TestGroupBy(t *testing.T) {
+	type Sale struct {
+		Region string
+		Sales  float64
+	}
+	sales := []Sale{{"east", 10}, {"west", 2.5}, {"east", 5}}
+	tb, err := Encode(sales)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := tb.GroupBy("Region", "Unknown").Aggregate(Sum("Sales"), Max("Sales").As("Top"), Count())
+	expected := "" +
+		" Region  sum(Sales)  Top  count \n" +
+		"  east       15      10     2   \n" +
+		"  west      2.5      2.5    1   \n"
+	if str := g.Format(WithTheme(ThemePlain)); str != expected {
+		t.Errorf("group:\n%q", str)
+	}
+
+	//one group of all rows
+	if str := tb.GroupBy().Aggregate(Avg("Sales"), Min("Sales")).Format(WithTheme(ThemePlain)); str != " avg(Sales)  min(Sales) \n   5.833        2.5     \n" {
+		t.Errorf("all:\n%q", str)
+	}
+}
+
+//inner and left joins on key
+func TestJoin(t *testing.T) {
+	pods, _ := Encode("Name Node\napi n1\ndb n2\nweb n1", WithTheme(ThemePlain))
+	metrics, _ := Encode("CPU Name\n20m web\n5m api\n7m api")
+
+	inner, err := Join(pods, metrics, "Name", JoinInner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if str := inner.Format(); str != " Name  Node  CPU \n api    n1   5m  \n api    n1   7m  \n web    n1   20m \n" {
+		t.Errorf("inner:\n%q", str)
+	}
+
+	left, _ := Join(pods, metrics, "Name", JoinLeft)
+	if str := left.Format(); str != " Name  Node  CPU \n api    n1   5m  \n api    n1   7m  \n  db    n2       \n web    n1   20m \n" {
+		t.Errorf("left:\n%q", str)
+	}
+
+	if _, err := Join(pods, metrics, "Node", JoinInner); err != ErrColumn {
+		t.Errorf("key: %v", err)
+	}
+}
+
+//union of columns by name
+func TestConcat(t *testing.T) {
+	a, _ := Encode("Host CPU\nweb 1", WithTheme(ThemePlain))
+	b, _ := Encode("Host Mem\ndb 2G")
+	c, _ := Encode("_ _\ncache 3")
+
+	expected := "" +
+		" Host   CPU  Mem \n" +
+		"  web    1    _  \n" +
+		"  db     _   2G  \n" +
+		" cache   3    _  \n"
+	if str := Concat(a, b, c).Format(); str != expected {
+		t.Errorf("concat:\n%q", str)
+	}
+	if tb := Concat(); tb.Headers != nil || len(tb.Rows) != 0 {
+		t.Errorf("empty: %v", tb)
+	}
+}
+
+//chained transforms leave the table as is
+func TestTransforms(t *testing.T) {
+	tb, _ := Encode("k Size Debug\na 2048 x\nb 10 y", WithTheme(ThemePlain))
+	kb := func(cell string) string { return cell + "B" }
+
+	str := tb.RenameColumn("k", "Key").MapColumn("Size", kb).DropColumn("Debug").DropColumn("Unknown").Format()
+	if str != " Key  Size  \n  a   2048B \n  b    10B  \n" {
+		t.Errorf("transforms:\n%q", str)
+	}
+	if str := tb.Format(); str != " k  Size  Debug \n a  2048    x   \n b   10     y   \n" {
+		t.Errorf("original:\n%q", str)
+	}
+}
@@ -0,0 +1,50 @@
+package table
+
+//copy of table with the header renamed, unknown name is ignored, options of the column are kept, such as
+//t.RenameColumn("k", "Key").MapColumn("Size", humanize).DropColumn("Debug")
+func (this *Table) RenameColumn(name, to string) *Table {
+	t := this.clone()
+	if col := t.ColumnIndex(name); col >= 0 {
+		t.Headers[col] = t.cell(to)
+		t.widen(col, t.Headers[col])
+	}
+	return t
+}
+
+//copy of table with data cells of column changed by f, unknown name is ignored
+func (this *Table) MapColumn(name string, f func(cell string) string) *Table {
+	t := this.clone()
+	col := t.ColumnIndex(name)
+	if col < 0 {
+		return t
+	}
+	for _, row := range t.Rows {
+		if col < len(row) {
+			row[col] = t.cell(f(row[col]))
+		}
+	}
+	t.ColumnWidths = t.state(nil).widths
+	return t
+}
+
+//copy of table without column, unknown name is ignored
+func (this *Table) DropColumn(name string) *Table {
+	t := this.clone()
+	if col := t.ColumnIndex(name); col >= 0 {
+		t.DeleteColumn(col)
+	}
+	return t
+}
+
+//copy of table, cells of the copy are changed without changing this
+func (this *Table) clone() *Table {
+	t := *this
+	t.Headers = append([]string(nil), this.Headers...)
+	t.Units = append([]string(nil), this.Units...)
+	t.ColumnWidths = append([]int(nil), this.ColumnWidths...)
+	t.Rows = make([][]string, len(this.Rows))
+	for i, row := range this.Rows {
+		t.Rows[i] = append([]string(nil), row...)
+	}
+	return &t
+}
@@ -0,0 +1,48 @@
+package table
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+)
+
+//ip address and network types shown by their String methods
+var netTypes = map[reflect.Type]bool{
+	reflect.TypeOf(net.IP{}):           true,
+	reflect.TypeOf(net.IPMask{}):       true,
+	reflect.TypeOf(net.IPNet{}):        true,
+	reflect.TypeOf(net.IPAddr{}):       true,
+	reflect.TypeOf(net.TCPAddr{}):      true,
+	reflect.TypeOf(net.UDPAddr{}):      true,
+	reflect.TypeOf(netip.Addr{}):       true,
+	reflect.TypeOf(netip.Prefix{}):     true,
+	reflect.TypeOf(netip.AddrPort{}):   true,
+	reflect.TypeOf(net.HardwareAddr{}): true,
+}
+
+//ip address or network type
+func isNet(t reflect.Type) bool {
+	return netTypes[t]
+}
+
+//text of non-nil ip address or network, such as 10.0.0.1/24, zero value is nil
+func (this *state) formatNet(v reflect.Value) (str string, ok bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if !isNet(v.Type()) {
+		return "", false
+	}
+	if v.IsZero() {
+		return this.nilText(), true
+	}
+
+	//some methods have pointer receivers
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p.Interface().(fmt.Stringer).String(), true
+}
@@ -0,0 +1,36 @@
+package table
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+)
+
+func TestNet(t *testing.T) {
+	type Host struct {
+		Name   string
+		IP     net.IP
+		Subnet net.IPNet
+		Addr   netip.Addr
+		Prefix *netip.Prefix
+	}
+
+	_, subnet, _ := net.ParseCIDR("10.0.0.0/24")
+	prefix := netip.MustParsePrefix("fd00::/64")
+	hosts := []Host{
+		{"a", net.ParseIP("10.0.0.1"), *subnet, netip.MustParseAddr("fd00::1"), &prefix},
+		{"b", nil, net.IPNet{}, netip.Addr{}, nil},
+	}
+
+	out := Format(hosts, WithTheme(ThemePlain))
+	expected := "" +
+		"    Name     IP       Subnet      Addr     Prefix   \n" +
+		" 1   a    10.0.0.1  10.0.0.0/24  fd00::1  fd00::/64 \n" +
+		" 2   b                                              \n"
+	if out != expected {
+		t.Errorf("hosts:\n%q", out)
+	}
+	if out := Format(subnet); out != Format("10.0.0.0/24") {
+		t.Errorf("single:\n%s", out)
+	}
+}
@@ -0,0 +1,149 @@
+package table
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+//rounding of float digits
+type RoundingMode int
+
+const (
+	//to nearest, ties to even digit, such as 0.125 -> 0.12
+	RoundHalfEven RoundingMode = iota
+	//to nearest, ties away from zero, such as 0.125 -> 0.13
+	RoundHalfUp
+	//toward zero, such as 0.129 -> 0.12
+	RoundDown
+	//away from zero, such as 0.121 -> 0.13
+	RoundUp
+)
+
+//exponent notation of floats
+type Notation int
+
+const (
+	//Go's shortest text, exponent for large and small floats such as 1e+06
+	NotationAuto Notation = iota
+	//always exponent such as 1.5e-09
+	NotationScientific
+	//never exponent such as 1000000
+	NotationPlain
+)
+
+//notations of notation tag
+var notationNames = map[string]Notation{
+	"auto":  NotationAuto,
+	"sci":   NotationScientific,
+	"plain": NotationPlain,
+}
+
+//force or forbid exponent of float fields of the named columns for one call, notation tags are overridden
+func WithNotations(notations map[string]Notation) Option {
+	return func(this *Options) {
+		this.Notations = notations
+	}
+}
+
+//show float fields of the named columns with digits after the point for one call, prec tags are overridden
+func WithPrecisions(precisions map[string]int) Option {
+	return func(this *Options) {
+		this.Precisions = precisions
+	}
+}
+
+//float text of value with digits after the point and notation,
+//false for other kinds and Go's text which is shortest and auto
+func (this *state) formatFloat(v reflect.Value, prec int, notation Notation) (str string, ok bool) {
+	if prec < 0 && notation == NotationAuto {
+		return "", false
+	}
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Float32 && v.Kind() != reflect.Float64 {
+		return "", false
+	}
+
+	bits := 64
+	if v.Kind() == reflect.Float32 {
+		bits = 32
+	}
+	if notation == NotationScientific {
+		return strconv.FormatFloat(v.Float(), 'e', prec, bits), true
+	}
+	str = strconv.FormatFloat(v.Float(), 'f', -1, bits)
+	if prec < 0 || strings.ContainsAny(str, "NI") {
+		//shortest, NaN and Inf
+		return str, true
+	}
+	return roundDecimal(str, prec, this.Rounding), true
+}
+
+//round decimal text such as -12.345 to prec digits after the point
+func roundDecimal(str string, prec int, mode RoundingMode) string {
+	neg := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(str, "-")
+	intPart, frac := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		intPart, frac = str[:i], str[i+1:]
+	}
+	for len(frac) < prec {
+		frac += "0"
+	}
+	digits := []byte(intPart + frac[:prec])
+	rest := strings.TrimRight(frac[prec:], "0")
+
+	//round away from zero or not
+	up := false
+	switch {
+	case rest == "":
+	case mode == RoundUp:
+		up = true
+	case mode == RoundHalfUp:
+		up = rest[0] >= '5'
+	case mode == RoundHalfEven:
+		up = rest[0] > '5' || rest[0] == '5' && (len(rest) > 1 || (digits[len(digits)-1]-'0')%2 == 1)
+	}
+	if up {
+		i := len(digits) - 1
+		for ; i >= 0 && digits[i] == '9'; i-- {
+			digits[i] = '0'
+		}
+		if i < 0 {
+			digits = append([]byte{'1'}, digits...)
+		} else {
+			digits[i]++
+		}
+	}
+
+	n := len(digits) - prec
+	res := string(digits[:n])
+	if prec > 0 {
+		res += "." + string(digits[n:])
+	}
+	if neg && strings.Trim(res, "0.") != "" {
+		res = "-" + res
+	}
+	return res
+}
+
+//digits of field after the point, -1 means the shortest text
+func (this *state) precision(field fieldInfo) int {
+	if prec, ok := this.Precisions[field.name]; ok && prec >= 0 {
+		return prec
+	}
+	return field.prec
+}
+
+//notation of field
+func (this *state) notation(field fieldInfo) Notation {
+	if notation, ok := this.Notations[field.name]; ok {
+		return notation
+	}
+	return field.notation
+}
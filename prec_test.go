@@ -0,0 +1,104 @@
+package table
+
+import (
+	"testing"
+)
+
+func TestPrecision(t *testing.T) {
+	type Quote struct {
+		Symbol string
+		Price  float64  `table:",,prec=2"`
+		Ratio  *float32 `table:",,prec=1"`
+		Raw    float64
+	}
+	ratio := float32(0.25)
+	quotes := []Quote{{"a", 1.005, &ratio, 0.1}, {"b", -0.001, nil, 1e6}}
+
+	expected := "" +
+		"    Symbol  Price  Ratio   Raw  \n" +
+		" 1    a     1.00    0.2    0.1  \n" +
+		" 2    b     0.00          1e+06 \n"
+	if str := Format(quotes, WithTheme(ThemePlain)); str != expected {
+		t.Errorf("precision:\n%q", str)
+	}
+
+	cases := []struct {
+		str      string
+		prec     int
+		mode     RoundingMode
+		expected string
+	}{
+		{"0.125", 2, RoundHalfEven, "0.12"},
+		{"0.135", 2, RoundHalfEven, "0.14"},
+		{"0.125", 2, RoundHalfUp, "0.13"},
+		{"0.129", 2, RoundDown, "0.12"},
+		{"0.121", 2, RoundUp, "0.13"},
+		{"-9.99", 1, RoundHalfUp, "-10.0"},
+		{"2.5", 0, RoundHalfEven, "2"},
+		{"7", 3, RoundHalfEven, "7.000"},
+	}
+	for _, c := range cases {
+		if str := roundDecimal(c.str, c.prec, c.mode); str != c.expected {
+			t.Errorf("round %s %d %d: %s", c.str, c.prec, c.mode, str)
+		}
+	}
+
+	str := Format(quotes, WithTheme(ThemePlain), WithPrecisions(map[string]int{"Raw": 0}), func(o *Options) { o.Rounding = RoundUp })
+	expected = "" +
+		"    Symbol  Price  Ratio    Raw   \n" +
+		" 1    a     1.01    0.3      1    \n" +
+		" 2    b     -0.01         1000000 \n"
+	if str != expected {
+		t.Errorf("options:\n%q", str)
+	}
+}
+
+//exponents forced and forbidden
+func TestNotation(t *testing.T) {
+	type Stat struct {
+		P     float64 `table:",,notation=sci,prec=2"`
+		Count float64 `table:",,notation=plain"`
+		Rate  float64
+	}
+	stats := []Stat{{0.000012345, 2.5e7, 3e-7}}
+
+	expected := "" +
+		"       P       Count    Rate  \n" +
+		" 1  1.23e-05  25000000  3e-07 \n"
+	if str := Format(stats, WithTheme(ThemePlain)); str != expected {
+		t.Errorf("tags:\n%q", str)
+	}
+
+	//auto with prec has fixed digits
+	str := Format(stats, WithTheme(ThemePlain), WithNotations(map[string]Notation{"P": NotationAuto, "Rate": NotationPlain}))
+	expected = "" +
+		"     P     Count      Rate    \n" +
+		" 1  0.00  25000000  0.0000003 \n"
+	if str != expected {
+		t.Errorf("options:\n%q", str)
+	}
+}
+
+//negatives in parentheses and red
+func TestAccounting(t *testing.T) {
+	type Entry struct {
+		Item   string
+		Amount float64 `table:",,prec=2"`
+	}
+	entries := []Entry{{"sale", 120}, {"refund", -12.5}}
+	accounting := func(o *Options) {
+		o.Accounting = true
+		o.NegativeStyle = "31"
+	}
+
+	expected := "" +
+		"     Item   Amount  \n" +
+		" 1   sale   120.00  \n" +
+		" 2  refund  \x1b[31m(12.50)\x1b[0m \n"
+	if str := Format(entries, WithTheme(ThemePlain), accounting); str != expected {
+		t.Errorf("accounting:\n%q", str)
+	}
+	if str := Format("a\n-1\nx-1", WithTheme(ThemePlain), func(o *Options) { o.NegativeStyle = "31" }); str != "  a  \n \x1b[31m-1\x1b[0m  \n x-1 \n" {
+		t.Errorf("negative style:\n%q", str)
+	}
+}
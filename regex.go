@@ -0,0 +1,49 @@
+package table
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+)
+
+//format lines matching re as table, named groups of re are columns and unnamed groups are ignored,
+//such as `(?P<time>\S+) (?P<level>\w+) (?P<msg>.*)`, lines not matching are skipped
+func FormatRegex(r io.Reader, re *regexp.Regexp, opts ...Option) (string, error) {
+	records, err := readRegex(r, re)
+	if err != nil {
+		return "", err
+	}
+	s := newState(Defaults(), opts)
+	if err := catch(func() { s.encodeRecords(records) }); err != nil {
+		return "", err
+	}
+	return s.format(), nil
+}
+
+//records of named groups, the first is header
+func readRegex(r io.Reader, re *regexp.Regexp) ([][]string, error) {
+	var groups []int
+	header := []string{}
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			groups = append(groups, i)
+			header = append(header, name)
+		}
+	}
+	records := [][]string{header}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		match := re.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		record := make([]string, len(groups))
+		for col, i := range groups {
+			record[col] = match[i]
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
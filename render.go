@@ -0,0 +1,587 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+//output buffers, reused between format calls
+var bufPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+//buffers larger than this are dropped instead of pooled
+const maxPooledBuffer = 1 << 22
+
+//end of output lines
+func (this *state) newline() string {
+	if this.LineEnding == "" {
+		return "\n"
+	}
+	return this.LineEnding
+}
+
+//pooled buffer, put it back by putBuffer
+func getBuffer() *bytes.Buffer {
+	buf := bufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+//pool buffer which is not too large
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBuffer {
+		bufPool.Put(buf)
+	}
+}
+
+//table format
+func (this *state) format() string {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	this.formatTo(buf)
+	return buf.String()
+}
+
+//append table format to buf
+func (this *state) formatTo(buf *bytes.Buffer) {
+	//no data without border
+	if this.isEmpty() && !this.EmptyBorder {
+		buf.WriteString(this.EmptyText + this.newline())
+		return
+	}
+
+	//normalized table
+	tb, colWidth := this.layout()
+	this.renderTo(buf, tb, colWidth)
+}
+
+//format cells with content widths of columns
+func (this *state) render(tb [][]string, colWidth []int) string {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	this.renderTo(buf, tb, colWidth)
+	return buf.String()
+}
+
+//bytes of output written at once by writeTo
+const chunkSize = 1 << 15
+
+//encode object and write its format to w, errors of w are returned
+func (this *state) runWriter(w io.Writer, obj interface{}) error {
+	if this.SubTables || this.Renderer != nil {
+		_, err := io.WriteString(w, this.run(obj))
+		return err
+	}
+	this.encode(obj)
+	_, err := this.writeTo(w)
+	return err
+}
+
+//write table format to w in chunks of rows, memory is bounded by the grid instead of the whole output
+func (this *state) writeTo(w io.Writer) (n int64, err error) {
+	cw := &countWriter{w: w}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	defer func() {
+		if r := recover(); r != nil {
+			a, ok := r.(abort)
+			if !ok {
+				panic(r)
+			}
+			n, err = cw.n, a.err
+		}
+	}()
+
+	this.out = cw
+	this.formatTo(buf)
+	this.flush(buf, 0)
+	return cw.n, nil
+}
+
+//write buf to out when it has min bytes, nothing when the output is buffered
+func (this *state) flush(buf *bytes.Buffer, min int) {
+	if this.out == nil || buf.Len() < min || buf.Len() == 0 {
+		return
+	}
+	if _, err := this.out.Write(buf.Bytes()); err != nil {
+		panic(abort{err})
+	}
+	buf.Reset()
+}
+
+//append cells with content widths of columns to buf
+func (this *state) renderTo(buf *bytes.Buffer, tb [][]string, colWidth []int) {
+	theme := this.theme()
+	if len(this.Heatmap) != 0 && !this.noColor() {
+		this.marks = append(this.marks, this.heatmap(tb[:len(tb)-this.footRows()]))
+	}
+	if this.NegativeStyle != "" {
+		this.marks = append(this.marks, this.negatives(this.headRows()))
+	}
+	if this.invalid != nil {
+		this.marks = append(this.marks, this.invalids)
+	}
+	if this.Highlight != nil {
+		foot := -1
+		if this.footer {
+			foot = len(tb) - 1
+		}
+		this.marks = append(this.marks, this.highlights(this.headRows(), foot))
+		for row := this.headRows(); row < len(tb) && row != foot; row++ {
+			if !this.isElided(row) {
+				this.starHighlights(tb[row], colWidth)
+			}
+		}
+	}
+	if theme.Border == MarkdownBorder {
+		for row, line := range tb {
+			if !this.isElided(row) {
+				this.escapePipes(line, colWidth)
+			}
+		}
+	}
+	this.columnWidth(theme, colWidth)
+	if this.omitted > 0 {
+		this.widenSpan(theme, colWidth, tb[this.elided][0])
+	}
+
+	//print table, chunks are not grown to the whole output
+	if this.out == nil {
+		buf.Grow(this.boardSize(theme, tb, colWidth))
+	}
+	this.boardFormat(buf, theme, tb, colWidth)
+}
+
+//add padding to column widths, line characters are wide in East Asian terminals,
+//round widths up to whole characters
+func (this *state) columnWidth(theme *Theme, colWidth []int) {
+	unit := 1
+	if theme.Border.Horizontal != "" {
+		unit = this.width(theme.Border.Horizontal)
+	}
+	for i := range colWidth {
+		colWidth[i] += 2 * theme.Padding
+		if colWidth[i] < theme.MinWidth {
+			colWidth[i] = theme.MinWidth
+		}
+		colWidth[i] += (unit - colWidth[i]%unit) % unit
+	}
+}
+
+//lines of theme for column widths
+type board struct {
+	theme    *Theme
+	colWidth []int
+	fill     []string
+	side     string
+	vertical string
+	head     int
+	//row of footer, -1 means none
+	foot int
+}
+
+//init lines of theme
+func (this *state) newBoard(theme *Theme, colWidth []int) *board {
+	b := &theme.Border
+	bd := &board{theme: theme, colWidth: colWidth, head: this.headRows(), foot: -1}
+
+	//init fill as --- ...
+	if b.Horizontal != "" {
+		unit := this.width(b.Horizontal)
+		bd.fill = make([]string, len(colWidth))
+		for i := range bd.fill {
+			bd.fill[i] = strings.Repeat(b.Horizontal, colWidth[i]/unit)
+		}
+	}
+
+	//vertical lines
+	bd.vertical = theme.BorderStyle.wrap(b.Vertical)
+	if b.Sides {
+		bd.side = bd.vertical
+	}
+	return bd
+}
+
+//format with border of theme
+func (this *state) boardFormat(buf *bytes.Buffer, theme *Theme, tb [][]string, colWidth []int) {
+	bd := this.newBoard(theme, colWidth)
+	if this.footer {
+		bd.foot = len(tb) - 1
+	}
+	sections := this.sections(tb, bd.head)
+
+	this.writeTop(buf, bd)
+	for row, line := range tb {
+		if row%checkBatch == 0 {
+			this.check()
+		}
+		this.writeTableRow(buf, bd, row, line, sections != nil && sections[row])
+		this.flush(buf, chunkSize)
+	}
+	this.writeBottom(buf, bd)
+}
+
+//write top line and blank header when the border needs one
+func (this *state) writeTop(buf *bytes.Buffer, bd *board) {
+	b := &bd.theme.Border
+
+	//init top ┌───┬───┐
+	if b.Frame {
+		this.writeLine(buf, bd, b.TopLeft, b.TopCenter, b.TopRight)
+	}
+
+	if bd.head == 0 && b.Header && !this.NoHeader {
+		this.writeRow(buf, bd, bd.theme.HeaderStyle, -1, make([]string, len(bd.colWidth)))
+		this.writeLine(buf, bd, b.MiddleLeft, b.MiddleCenter, b.MiddleRight)
+	}
+}
+
+//write row of table with the line above it
+func (this *state) writeTableRow(buf *bytes.Buffer, bd *board, row int, line []string, section bool) {
+	b := &bd.theme.Border
+
+	//init middle ├───┼───┤, header rows are not separated, footer always is
+	if row != 0 && (row == bd.head || row > bd.head && (b.RowLines || section) || row == bd.foot) {
+		this.writeLine(buf, bd, b.MiddleLeft, b.MiddleCenter, b.MiddleRight)
+	}
+
+	if this.isElided(row) {
+		this.writeSpan(buf, bd, line[0])
+		return
+	}
+
+	style := bd.theme.CellStyle
+	if row < bd.head || row == bd.foot {
+		style = bd.theme.HeaderStyle
+	}
+	this.writeRow(buf, bd, style, row, line)
+}
+
+//width of all the columns and vertical lines between them
+func (this *state) spanWidth(theme *Theme, colWidth []int) int {
+	width := this.width(theme.Border.Vertical) * (len(colWidth) - 1)
+	for _, w := range colWidth {
+		width += w
+	}
+	return width
+}
+
+//widen the last column for text spanning all the columns
+func (this *state) widenSpan(theme *Theme, colWidth []int, text string) {
+	if need := this.width(text) + 2*theme.Padding - this.spanWidth(theme, colWidth); need > 0 {
+		colWidth[len(colWidth)-1] += need
+	}
+}
+
+//write text centered across all the columns, such as elision row
+func (this *state) writeSpan(buf *bytes.Buffer, bd *board, text string) {
+	width := this.spanWidth(bd.theme, bd.colWidth)
+	buf.WriteString(bd.side)
+	this.writeCell(buf, bd.theme, AlignCenter, bd.theme.CellStyle.wrap(text), width, 0)
+	buf.WriteString(bd.side)
+	buf.WriteString(this.newline())
+}
+
+//write bottom line
+func (this *state) writeBottom(buf *bytes.Buffer, bd *board) {
+	b := &bd.theme.Border
+
+	//init bottom └───┴───┘
+	if b.Frame {
+		this.writeLine(buf, bd, b.BottomLeft, b.BottomCenter, b.BottomRight)
+	}
+}
+
+//write horizontal line, nothing when the border has no horizontal character
+func (this *state) writeLine(buf *bytes.Buffer, bd *board, left, center, right string) {
+	if bd.fill == nil {
+		return
+	}
+	if !bd.theme.Border.Sides {
+		left, right = "", ""
+	}
+	buf.WriteString(bd.theme.BorderStyle.wrap(strings.Join(initLine(left, center, right, bd.fill), "")))
+	buf.WriteString(this.newline())
+}
+
+//write row with vertical lines, multi-line cells make the row higher
+func (this *state) writeRow(buf *bytes.Buffer, bd *board, style Style, row int, line []string) {
+	//styles of marked cells
+	var styles []Style
+	if this.marks != nil && row >= 0 && !this.noColor() {
+		styles = make([]Style, len(line))
+		for col, val := range line {
+			styles[col] = style
+			for _, mark := range this.marks {
+				if s := mark(row, col, val); s != "" {
+					styles[col] = s
+					break
+				}
+			}
+		}
+	}
+
+	//padding runes of data rows
+	pads := this.pads
+	if row < bd.head || row == bd.foot {
+		pads = nil
+	}
+
+	height := 1
+	for _, val := range line {
+		if n := strings.Count(val, "\n") + 1; n > height {
+			height = n
+		}
+	}
+
+	//split multi-line cells
+	var cells [][]string
+	if height > 1 {
+		cells = make([][]string, len(line))
+		for col, val := range line {
+			cells[col] = strings.Split(val, "\n")
+		}
+	}
+
+	for i := 0; i < height; i++ {
+		buf.WriteString(bd.side)
+		for col, val := range line {
+			if cells != nil {
+				val = ""
+				if i < len(cells[col]) {
+					val = cells[col][i]
+				}
+			}
+			if col != 0 {
+				buf.WriteString(bd.vertical)
+			}
+			s := style
+			if styles != nil {
+				s = styles[col]
+			}
+			var pad rune
+			if col < len(pads) {
+				pad = pads[col]
+			}
+			this.writeCell(buf, bd.theme, this.align(bd.theme, col), s.wrap(val), bd.colWidth[col], pad)
+		}
+		buf.WriteString(bd.side)
+		buf.WriteString(this.newline())
+	}
+}
+
+//alignment of column, Layout and Schema override theme
+func (this *state) align(theme *Theme, col int) Align {
+	if col < len(this.aligns) && this.aligns[col] != AlignDefault {
+		return this.aligns[col]
+	}
+	return theme.Align
+}
+
+//write cell aligned in the column width, space out of theme padding is filled with pad rune,
+//0 means CenterFilling
+func (this *state) writeCell(buf *bytes.Buffer, theme *Theme, align Align, val string, colWidth int, pad rune) {
+	size := this.width(val)
+	padding := theme.Padding
+	if colWidth-size < 2*padding {
+		padding = (colWidth - size) / 2
+	}
+
+	var left int
+	switch align {
+	case AlignLeft:
+		left = padding
+	case AlignRight:
+		left = colWidth - size - padding
+	default:
+		left = (colWidth - size) / 2
+	}
+	right := colWidth - size - left
+
+	if pad == 0 {
+		this.writeFilling(buf, left)
+		buf.WriteString(val)
+		this.writeFilling(buf, right)
+		return
+	}
+	this.writeFilling(buf, padding)
+	this.writePad(buf, left-padding, pad)
+	buf.WriteString(val)
+	this.writePad(buf, right-padding, pad)
+	this.writeFilling(buf, padding)
+}
+
+//write n CenterFilling
+func (this *state) writeFilling(buf *bytes.Buffer, n int) {
+	for i := 0; i < n; i++ {
+		buf.WriteByte(this.CenterFilling)
+	}
+}
+
+//write pad runes of n cells, the rest of wide rune is CenterFilling
+func (this *state) writePad(buf *bytes.Buffer, n int, pad rune) {
+	size := this.runeWidth(pad)
+	if size == 0 {
+		this.writeFilling(buf, n)
+		return
+	}
+	for i := 0; i < n/size; i++ {
+		buf.WriteRune(pad)
+	}
+	this.writeFilling(buf, n%size)
+}
+
+//bytes of the aligned cells of a row
+func (this *state) rowSize(line []string, colWidth []int) (size int) {
+	for col, val := range line {
+		size += len(val) + colWidth[col] - this.width(val)
+	}
+	return size
+}
+
+//bytes of output, styles are not counted
+func (this *state) boardSize(theme *Theme, tb [][]string, colWidth []int) (size int) {
+	b := &theme.Border
+	vertical := len(b.Vertical) * (len(colWidth) - 1)
+	if b.Sides {
+		vertical += 2 * len(b.Vertical)
+	}
+
+	//top, middle and bottom lines
+	if b.Horizontal != "" {
+		sum := 0
+		for _, w := range colWidth {
+			sum += w
+		}
+		border := sum/this.width(b.Horizontal)*len(b.Horizontal) + vertical + 1
+		size = border * (len(tb) + 1)
+	}
+
+	for _, line := range tb {
+		size += this.rowSize(line, colWidth) + vertical + 1
+	}
+	return size
+}
+
+//split str and filt empty line
+func (this *state) getLines(str string) []string {
+	var lines []string
+	if this.RowSeparator == "" {
+		lines = strings.Fields(str)
+	} else {
+		lines = strings.Split(str, this.RowSeparator)
+	}
+
+	//filt empty string
+	ret := []string{}
+	for _, f := range lines {
+		if len(f) > 0 {
+			ret = append(ret, f)
+		}
+	}
+	return ret
+}
+
+//split line and filt empty elements
+func (this *state) getFields(line string) []string {
+	var fields []string
+	if this.ColumnSeparator == "" {
+		fields = strings.Fields(line)
+	} else {
+		fields = strings.Split(line, this.ColumnSeparator)
+	}
+
+	//filt empty string
+	ret := []string{}
+	for _, f := range fields {
+		if len(f) > 0 {
+			ret = append(ret, f)
+		}
+	}
+	return ret
+}
+
+//change all the space character (\t \n _ \b) to space, expand tab to tab stops when TabWidth is set,
+//show control characters as escapes when EscapeControl is set
+func (this *state) handleSpace(str string) string {
+	//nothing to change
+	if strings.IndexFunc(str, this.isSpecial) < 0 {
+		return str
+	}
+
+	var buf strings.Builder
+	buf.Grow(len(str))
+	for i := 0; i < len(str); {
+		c, size := utf8.DecodeRuneInString(str[i:])
+		switch {
+		case c == '\t' && this.TabWidth > 0:
+			n := this.TabWidth - this.width(buf.String())%this.TabWidth
+			buf.WriteString(strings.Repeat(" ", n))
+		case this.EscapeControl && (unicode.IsControl(c) || c == utf8.RuneError && size == 1):
+			writeEscaped(&buf, str[i:i+size], c)
+		case isSpaceAlt(c):
+			buf.WriteRune(rune(this.SpaceAlt))
+		default:
+			buf.WriteString(str[i : i+size])
+		}
+		i += size
+	}
+	return buf.String()
+}
+
+//character to be changed by handleSpace
+func (this *state) isSpecial(c rune) bool {
+	if this.EscapeControl && (unicode.IsControl(c) || c == utf8.RuneError) {
+		return true
+	}
+	return isSpaceAlt(c)
+}
+
+//write control character or invalid byte as Go escape
+func writeEscaped(buf *strings.Builder, raw string, c rune) {
+	switch {
+	case c == utf8.RuneError:
+		fmt.Fprintf(buf, "\\x%02x", raw[0])
+	case c < utf8.RuneSelf:
+		//\a \b \f \n \r \t \v and \xHH
+		quoted := strconv.QuoteRune(c)
+		buf.WriteString(quoted[1 : len(quoted)-1])
+	default:
+		fmt.Fprintf(buf, "\\u%04x", c)
+	}
+}
+
+//space character to be replaced
+func isSpaceAlt(c rune) bool {
+	return unicode.IsSpace(c) && c != ' '
+}
+
+//form table line
+func initLine(left, center, right string, fill []string) []string {
+	colNum := len(fill)*2 + 1
+	line := make([]string, colNum)
+	for i, _ := range line {
+		tmp := ""
+		switch {
+		case i == 0:
+			tmp = left
+		case i == colNum-1:
+			tmp = right
+		case i%2 == 0:
+			tmp = center
+		default:
+			tmp = fill[i/2]
+		}
+		line[i] = tmp
+	}
+	return line
+}
@@ -0,0 +1,115 @@
+package table
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+//output format of encoded tables, such as text, html or a format of other packages
+type Renderer interface {
+	Render(t *Table, w io.Writer) error
+}
+
+//function as renderer
+type RendererFunc func(t *Table, w io.Writer) error
+
+//call f
+func (this RendererFunc) Render(t *Table, w io.Writer) error {
+	return this(t, w)
+}
+
+//predefined renderers
+var (
+	//text table of theme
+	TextRenderer Renderer = RendererFunc(func(t *Table, w io.Writer) error {
+		_, err := t.state(nil).writeTo(w)
+		return err
+	})
+
+	//html table
+	HTMLRenderer Renderer = RendererFunc(func(t *Table, w io.Writer) error {
+		_, err := io.WriteString(w, t.FormatHTML())
+		return err
+	})
+)
+
+//name -> Renderer
+var renderers sync.Map
+
+func init() {
+	RegisterRenderer("text", TextRenderer)
+	RegisterRenderer("html", HTMLRenderer)
+	RegisterRenderer("csv", CSVRenderer)
+	RegisterRenderer("tsv", TSVRenderer)
+	RegisterRenderer("svg", SVGRenderer)
+}
+
+//register renderer by name for RendererOf, such as "xlsx", nil r removes the renderer
+func RegisterRenderer(name string, r Renderer) {
+	if r == nil {
+		renderers.Delete(name)
+		return
+	}
+	renderers.Store(name, r)
+}
+
+//registered renderer of name, "text", "html", "csv", "tsv" and "svg" are predefined
+func RendererOf(name string) (r Renderer, ok bool) {
+	v, ok := renderers.Load(name)
+	if !ok {
+		return nil, false
+	}
+	return v.(Renderer), true
+}
+
+//format with renderer for one call
+func WithRenderer(r Renderer) Option {
+	return func(this *Options) {
+		this.Renderer = r
+	}
+}
+
+//format table as html with its options
+func (this *Table) FormatHTML(opts ...Option) string {
+	return this.state(opts).formatHTML()
+}
+
+//encode object and render it by Renderer, errors are shown as text
+func (this *state) runRenderer(obj interface{}) string {
+	this.encode(obj)
+	var buf bytes.Buffer
+	if err := this.Renderer.Render(this.table(), &buf); err != nil {
+		buf.WriteString(err.Error())
+		buf.WriteString(this.newline())
+	}
+	return buf.String()
+}
+
+//text table of theme, Table is a fmt.Stringer
+func (this *Table) String() string {
+	return this.Format()
+}
+
+//render table to w by Renderer of its options or as text, Table is an io.WriterTo
+func (this *Table) WriteTo(w io.Writer) (n int64, err error) {
+	r := this.options.Renderer
+	if r == nil {
+		r = TextRenderer
+	}
+	cw := &countWriter{w: w}
+	err = r.Render(this, cw)
+	return cw.n, err
+}
+
+//writer counting written bytes
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (this *countWriter) Write(p []byte) (int, error) {
+	n, err := this.w.Write(p)
+	this.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,99 @@
+package table
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//show every nth data row with a row telling the sampling, such as enormous datasets
+func WithSampleEvery(n int) Option {
+	return func(this *Options) {
+		this.SampleEvery = n
+	}
+}
+
+//show size random data rows in their order with a row telling the sampling, seed 0 means a random seed
+func WithSample(size int, seed int64) Option {
+	return func(this *Options) {
+		this.SampleSize, this.SampleSeed = size, seed
+	}
+}
+
+//keep data rows of SampleEvery or SampleSize and add a note row after them, footer is kept
+func (this *state) sample() {
+	head := this.headRows()
+	end := len(this.cells) - this.footRows()
+	total := end - head
+
+	var rows []int
+	var text string
+	switch {
+	case this.SampleEvery > 1 && total > 1:
+		for row := head; row < end; row += this.SampleEvery {
+			rows = append(rows, row)
+		}
+		text = "every " + ordinal(this.SampleEvery) + " of " + strconv.Itoa(total) + " rows"
+	case this.SampleSize > 0 && this.SampleSize < total:
+		seed := this.SampleSeed
+		if seed == 0 && !this.Deterministic {
+			seed = time.Now().UnixNano()
+		}
+		rows = rand.New(rand.NewSource(seed)).Perm(total)[:this.SampleSize]
+		sort.Ints(rows)
+		for i := range rows {
+			rows[i] += head
+		}
+		text = strconv.Itoa(this.SampleSize) + " random of " + strconv.Itoa(total) + " rows"
+	default:
+		return
+	}
+
+	//sampled rows and note row
+	kept := make(map[int]int, len(rows))
+	cells := make([][]string, 0, head+len(rows)+1+this.footRows())
+	cells = append(cells, this.cells[:head]...)
+	for _, row := range rows {
+		kept[row] = len(cells)
+		cells = append(cells, this.cells[row])
+	}
+	mark := this.cutMark()
+	line := make([]string, this.colNum)
+	line[0] = mark + " " + text + " " + mark
+	note := len(cells)
+	cells = append(cells, line)
+	this.cells = append(cells, this.cells[end:]...)
+
+	this.moveRows(func(row int) int {
+		switch {
+		case row < head:
+			return row
+		case row >= end:
+			return row - end + note + 1
+		}
+		if to, ok := kept[row]; ok {
+			return to
+		}
+		return -1
+	})
+	this.omitted, this.elided = total-len(rows), note
+	this.measure()
+}
+
+//english ordinal of n, such as 2nd and 11th
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 10 {
+	case 1:
+		suffix = "st"
+	case 2:
+		suffix = "nd"
+	case 3:
+		suffix = "rd"
+	}
+	if n%100 >= 11 && n%100 <= 13 {
+		suffix = "th"
+	}
+	return strconv.Itoa(n) + suffix
+}
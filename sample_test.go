@@ -0,0 +1,33 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+//every nth row and random rows with a note row
+func TestSample(t *testing.T) {
+	var rows strings.Builder
+	rows.WriteString("ID\n")
+	for i := 1; i <= 10; i++ {
+		rows.WriteString(strconv.Itoa(i) + "\n")
+	}
+	plain := WithTheme(ThemePlain)
+
+	if str := Format(rows.String(), plain, WithSampleEvery(4)); str != "            ID            \n            1             \n            5             \n            9             \n … every 4th of 10 rows … \n" {
+		t.Errorf("every:\n%q", str)
+	}
+
+	//same seed, same rows
+	str := Format(rows.String(), plain, WithSample(3, 42))
+	if str != Format(rows.String(), plain, WithSample(3, 42)) || strings.Count(str, "\n") != 5 || !strings.Contains(str, "… 3 random of 10 rows …") {
+		t.Errorf("random:\n%q", str)
+	}
+
+	for n, expected := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 102: "102nd", 111: "111th"} {
+		if ordinal(n) != expected {
+			t.Errorf("%d: %s", n, ordinal(n))
+		}
+	}
+}
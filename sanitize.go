@@ -0,0 +1,100 @@
+package table
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//drop terminal escape sequences, bidi controls and other control characters of untrusted cell,
+//line breaks and tabs are kept, invalid bytes become U+FFFD
+func sanitize(str string) string {
+	if isSafe(str) {
+		return str
+	}
+
+	var buf strings.Builder
+	buf.Grow(len(str))
+	for i := 0; i < len(str); {
+		c, size := utf8.DecodeRuneInString(str[i:])
+		switch {
+		case c == 0x1b:
+			i += escapeSize(str[i:])
+			continue
+		case c == 0x9b:
+			//CSI of C1
+			i += size + csiSize(str[i+size:])
+			continue
+		case c == 0x90 || c == 0x98 || c == 0x9d || c == 0x9e || c == 0x9f:
+			//strings of C1 such as OSC
+			i += size + stringSize(str[i+size:])
+			continue
+		case c == utf8.RuneError && size == 1:
+			buf.WriteRune(utf8.RuneError)
+		case c == '\n' || c == '\t':
+			buf.WriteByte(byte(c))
+		case unicode.IsControl(c) || isBidi(c):
+		default:
+			buf.WriteString(str[i : i+size])
+		}
+		i += size
+	}
+	return buf.String()
+}
+
+//no byte to be dropped
+func isSafe(str string) bool {
+	for _, c := range str {
+		if c == '\n' || c == '\t' {
+			continue
+		}
+		if unicode.IsControl(c) || isBidi(c) || c == utf8.RuneError {
+			return false
+		}
+	}
+	return true
+}
+
+//bidi controls which reorder the text around them, such as right-to-left override
+func isBidi(c rune) bool {
+	return c == 0x061c || c == 0x200e || c == 0x200f || c >= 0x202a && c <= 0x202e || c >= 0x2066 && c <= 0x2069
+}
+
+//bytes of escape sequence at the start of str
+func escapeSize(str string) int {
+	if len(str) < 2 {
+		return len(str)
+	}
+	switch str[1] {
+	case '[':
+		return 2 + csiSize(str[2:])
+	case ']', 'P', 'X', '^', '_':
+		return 2 + stringSize(str[2:])
+	}
+	return 2
+}
+
+//bytes of parameters and final byte of CSI sequence
+func csiSize(str string) int {
+	for i := 0; i < len(str); i++ {
+		if str[i] >= 0x40 && str[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(str)
+}
+
+//bytes of string of OSC and DCS sequences with the terminator, BEL or ST
+func stringSize(str string) int {
+	for i := 0; i < len(str); i++ {
+		switch {
+		case str[i] == 0x07:
+			return i + 1
+		case str[i] == 0x1b && i+1 < len(str) && str[i+1] == '\\':
+			return i + 2
+		case strings.HasPrefix(str[i:], "\u009c"):
+			return i + len("\u009c")
+		}
+	}
+	return len(str)
+}
@@ -0,0 +1,143 @@
+package table
+
+import (
+	"fmt"
+	"strconv"
+)
+
+//rules of columns matched by header name, such as one schema for all the tables of an application,
+//columns of tables without header are matched by index, Value of columns is not used
+type Schema []Column
+
+//format with schema for one call, rules of schema override tags
+func WithSchema(schema Schema) Option {
+	return func(this *Options) {
+		this.Schema = schema
+	}
+}
+
+//highlight data cells not fitting the types of Schema with style instead of aborting
+func WithInvalidStyle(style Style) Option {
+	return func(this *Options) {
+		this.InvalidStyle = style
+	}
+}
+
+//error of row not fitting Schema, FormatContext returns it and Format shows it
+type SchemaError struct {
+	//data row from 1
+	Row int
+	//header name, empty when the row has too many or too few cells
+	Column string
+	Reason string
+}
+
+func (this *SchemaError) Error() string {
+	if this.Column == "" {
+		return fmt.Sprintf("table: row %d: %s", this.Row, this.Reason)
+	}
+	return fmt.Sprintf("table: row %d column %s: %s", this.Row, this.Column, this.Reason)
+}
+
+//masks of cells fitting column types
+var typeMasks = map[ColumnType]int{
+	TypeInt:   maskInt,
+	TypeFloat: maskFloat,
+	TypeBool:  maskBool,
+	TypeTime:  maskTime,
+}
+
+//check data cell against the type of its column, empty cells fit every type
+func (this *state) validate(col int, val string) {
+	typ := this.types[col]
+	if typ == TypeString || val == "" || val == this.Placeholder || cellMask(val, this.BoolText)&typeMasks[typ] != 0 {
+		return
+	}
+
+	row := len(this.cells)
+	if this.InvalidStyle == "" {
+		name := strconv.Itoa(col + 1)
+		if col < len(this.names) {
+			name = this.names[col]
+		}
+		reason := fmt.Sprintf("%q is not %s", val, typ)
+		panic(abort{&SchemaError{Row: row - this.headRows() + 1, Column: name, Reason: reason}})
+	}
+	if this.invalid == nil {
+		this.invalid = make(map[[2]int]bool)
+	}
+	this.invalid[[2]int{row, col}] = true
+}
+
+//mark invalid cells with InvalidStyle
+func (this *state) invalids(row, col int, val string) Style {
+	if this.invalid[[2]int{row, col}] {
+		return this.InvalidStyle
+	}
+	return ""
+}
+
+//set rules of schema columns, names is nil when the table has no header
+func (this *state) applySchema(names []string) {
+	for col := 0; col < this.colNum; col++ {
+		c, ok := this.schemaColumn(col, names)
+		if !ok {
+			continue
+		}
+		if c.Align != AlignDefault {
+			if this.aligns == nil {
+				this.aligns = make([]Align, this.colNum)
+			}
+			this.aligns[col] = c.Align
+		}
+		if c.Width > 0 {
+			if this.fixed == nil {
+				this.fixed = make([]int, this.colNum)
+			}
+			this.fixed[col] = c.Width
+		}
+		if c.Format != nil {
+			if this.formats == nil {
+				this.formats = make([]func(cell string) string, this.colNum)
+			}
+			this.formats[col] = c.Format
+		}
+		if c.Footer != "" {
+			if this.footers == nil {
+				this.footers = make([]string, this.colNum)
+			}
+			this.footers[col] = c.Footer
+		}
+		if c.Type != TypeString {
+			if this.types == nil {
+				this.types = make([]ColumnType, this.colNum)
+			}
+			this.types[col] = c.Type
+		}
+	}
+	this.applyLayout()
+}
+
+//schema column of column by header name or index
+func (this *state) schemaColumn(col int, names []string) (c Column, ok bool) {
+	if names == nil {
+		if col < len(this.Schema) {
+			return this.Schema[col], true
+		}
+		return c, false
+	}
+	for _, c := range this.Schema {
+		if col < len(names) && c.Name == names[col] {
+			return c, true
+		}
+	}
+	return c, false
+}
+
+//text of data cell by schema format
+func (this *state) formatCell(col int, val string) string {
+	if col < len(this.formats) && this.formats[col] != nil {
+		return this.formats[col](val)
+	}
+	return val
+}
@@ -0,0 +1,204 @@
+//go:build go1.21
+// +build go1.21
+
+package table
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+//slog handler keeping records as rows of a table, such as summaries of tests and batch jobs,
+//keys of attributes are columns in order of appearance and keys of groups are joined by dots, for example:
+//
+//	h := table.NewLogTable(nil)
+//	slog.New(h).Info("copied", "file", name, "bytes", n)
+//	h.Flush(os.Stdout)
+//
+//Table returns the records for GroupBy, such as h.Table().GroupBy("job").Aggregate(table.Count())
+type LogTable struct {
+	opts   slog.HandlerOptions
+	groups []string
+	//attributes of WithAttrs
+	keys, vals []string
+	records    *logRecords
+}
+
+//records shared by handlers of WithAttrs and WithGroup
+type logRecords struct {
+	sync.Mutex
+	index  map[string]int
+	header []string
+	rows   [][]string
+}
+
+//handler of records of opts.Level, nil opts means info level, ReplaceAttr changes or drops attributes
+func NewLogTable(opts *slog.HandlerOptions) *LogTable {
+	h := &LogTable{records: &logRecords{index: map[string]int{}}}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h
+}
+
+//level is not lower than Level of options
+func (this *LogTable) Enabled(_ context.Context, level slog.Level) bool {
+	min := slog.LevelInfo
+	if this.opts.Level != nil {
+		min = this.opts.Level.Level()
+	}
+	return level >= min
+}
+
+//add record as row with time, level, message and attributes
+func (this *LogTable) Handle(_ context.Context, r slog.Record) error {
+	var keys, vals []string
+	add := func(groups []string, a slog.Attr) {
+		keys, vals = this.flatten(keys, vals, groups, a)
+	}
+	if !r.Time.IsZero() {
+		add(nil, slog.Time(slog.TimeKey, r.Time))
+	}
+	add(nil, slog.Any(slog.LevelKey, r.Level))
+	add(nil, slog.String(slog.MessageKey, r.Message))
+	keys, vals = append(keys, this.keys...), append(vals, this.vals...)
+	r.Attrs(func(a slog.Attr) bool {
+		add(this.groups, a)
+		return true
+	})
+	this.records.add(keys, vals)
+	return nil
+}
+
+//handler with attributes added to every record
+func (this *LogTable) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h := *this
+	h.keys, h.vals = append([]string{}, this.keys...), append([]string{}, this.vals...)
+	for _, a := range attrs {
+		h.keys, h.vals = this.flatten(h.keys, h.vals, this.groups, a)
+	}
+	return &h
+}
+
+//handler with keys of later attributes in group
+func (this *LogTable) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return this
+	}
+	h := *this
+	h.groups = append(this.groups[:len(this.groups):len(this.groups)], name)
+	return &h
+}
+
+//table of the records with a column per key, opts are options of encoding such as Schema
+func (this *LogTable) Table(opts ...Option) (*Table, error) {
+	return recordsTable(this.records.snapshot(), opts)
+}
+
+//write table of the records to w and drop them, records are kept when encoding fails
+func (this *LogTable) Flush(w io.Writer, opts ...Option) error {
+	var t *Table
+	err := this.records.take(func(records [][]string) (err error) {
+		t, err = recordsTable(records, opts)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	_, err = t.WriteTo(w)
+	return err
+}
+
+//table of records, the first is header
+func recordsTable(records [][]string, opts []Option) (*Table, error) {
+	s := newState(Defaults(), opts)
+	if err := catch(func() { s.encodeRecords(records) }); err != nil {
+		return nil, err
+	}
+	return s.table(), nil
+}
+
+//append keys and texts of attribute, groups are flattened, empty attributes are dropped
+func (this *LogTable) flatten(keys, vals []string, groups []string, a slog.Attr) ([]string, []string) {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup && this.opts.ReplaceAttr != nil {
+		a = this.opts.ReplaceAttr(groups, a)
+		a.Value = a.Value.Resolve()
+	}
+	if a.Equal(slog.Attr{}) {
+		return keys, vals
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			groups = append(groups[:len(groups):len(groups)], a.Key)
+		}
+		for _, sub := range a.Value.Group() {
+			keys, vals = this.flatten(keys, vals, groups, sub)
+		}
+		return keys, vals
+	}
+
+	key := strings.Join(append(groups[:len(groups):len(groups)], a.Key), ".")
+	val := a.Value.String()
+	if a.Value.Kind() == slog.KindTime {
+		val = a.Value.Time().Format(time.RFC3339)
+		if a.Key == slog.TimeKey && len(groups) == 0 {
+			val = a.Value.Time().Format("15:04:05.000")
+		}
+	}
+	return append(keys, key), append(vals, val)
+}
+
+//add row of keys and values, new keys are new columns
+func (this *logRecords) add(keys, vals []string) {
+	this.Lock()
+	defer this.Unlock()
+	row := make([]string, len(this.header))
+	for i, key := range keys {
+		col, ok := this.index[key]
+		if !ok {
+			col = len(this.header)
+			this.index[key] = col
+			this.header = append(this.header, key)
+			row = append(row, "")
+		}
+		row[col] = vals[i]
+	}
+	this.rows = append(this.rows, row)
+}
+
+//header and rows of the same length
+func (this *logRecords) snapshot() [][]string {
+	this.Lock()
+	defer this.Unlock()
+	return this.records()
+}
+
+//pass header and rows to f and drop them under the same lock unless f fails
+func (this *logRecords) take(f func(records [][]string) error) error {
+	this.Lock()
+	defer this.Unlock()
+	if err := f(this.records()); err != nil {
+		return err
+	}
+	this.index, this.header, this.rows = map[string]int{}, nil, nil
+	return nil
+}
+
+//copy of header and rows padded to the header, the caller holds the lock
+func (this *logRecords) records() [][]string {
+	if len(this.rows) == 0 {
+		return nil
+	}
+	records := [][]string{append([]string{}, this.header...)}
+	for _, row := range this.rows {
+		records = append(records, append(append([]string{}, row...), make([]string, len(this.header)-len(row))...))
+	}
+	return records
+}
+
@@ -0,0 +1,91 @@
+//go:build go1.21
+// +build go1.21
+
+package table
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+//records as rows, attributes as columns
+func TestLogTable(t *testing.T) {
+	noTime := func(groups []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey && len(groups) == 0 {
+			return slog.Attr{}
+		}
+		return a
+	}
+	h := NewLogTable(&slog.HandlerOptions{ReplaceAttr: noTime})
+	log := slog.New(h).With("job", "backup")
+	log.Debug("hidden")
+	log.Info("copied", "file", "a.txt", "bytes", 12)
+	log.WithGroup("err").Warn("skipped", "file", "b.txt", "code", 13)
+	log.Info("copied", "file", "c.txt", "bytes", 30)
+
+	expected := "" +
+		" level    msg     job    file   bytes  err.file  err.code \n" +
+		" INFO   copied   backup  a.txt   12                       \n" +
+		" WARN   skipped  backup                 b.txt       13    \n" +
+		" INFO   copied   backup  c.txt   30                       \n"
+	tb, err := h.Table()
+	if str := tb.Format(WithTheme(ThemePlain)); err != nil || str != expected {
+		t.Errorf("table %v:\n%q", err, str)
+	}
+
+	//schema errors are returned
+	if _, err := h.Table(WithSchema(Schema{{Name: "file", Type: TypeInt}})); err == nil {
+		t.Errorf("schema error expected")
+	}
+	if err := h.Flush(new(bytes.Buffer), WithSchema(Schema{{Name: "file", Type: TypeInt}})); err == nil {
+		t.Errorf("flush schema error expected")
+	}
+
+	g := tb.GroupBy("msg").Aggregate(Count(), Sum("bytes"))
+	if str := g.Format(WithTheme(ThemePlain)); str != "   msg    count  sum(bytes) \n copied     2        42     \n skipped    1        0      \n" {
+		t.Errorf("group:\n%q", str)
+	}
+
+	var buf bytes.Buffer
+	if err := h.Flush(&buf, WithTheme(ThemePlain)); err != nil || buf.String() != expected {
+		t.Errorf("flush: %v\n%q", err, buf.String())
+	}
+	if tb, _ := h.Table(); tb.Headers != nil || len(tb.Rows) != 0 {
+		t.Errorf("records are not dropped: %v", tb)
+	}
+}
+
+//records logged during flushes are not lost
+func TestLogTableFlush(t *testing.T) {
+	h := NewLogTable(nil)
+	log := slog.New(h)
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 1000; i++ {
+			log.Info("tick", "i", i)
+		}
+		close(done)
+	}()
+
+	csv := WithRenderer(CSVRenderer)
+	lines := 0
+	for finished := false; !finished; {
+		select {
+		case <-done:
+			finished = true
+		default:
+		}
+		var buf bytes.Buffer
+		if err := h.Flush(&buf, csv); err != nil {
+			t.Fatal(err)
+		}
+		if n := strings.Count(buf.String(), "\n"); n > 0 {
+			lines += n - 1
+		}
+	}
+	if lines != 1000 {
+		t.Errorf("flushed %d records", lines)
+	}
+}
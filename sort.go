@@ -0,0 +1,196 @@
+package table
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//sort data rows by the named columns for one call, a name prefixed with - sorts descending
+func WithSort(names ...string) Option {
+	return func(this *Options) {
+		this.SortColumns = append([]string{}, names...)
+	}
+}
+
+//compare texts, -1, 0 or 1, digit runs are compared by value for NaturalSort,
+//texts equal by SortFold or SortLocale are compared by bytes
+func (this *state) compare(a, b string) int {
+	cmp := strings.Compare
+	if this.NaturalSort {
+		cmp = naturalCompare
+	}
+	if this.SortFold || this.SortLocale != "" {
+		if c := cmp(this.sortKey(a), this.sortKey(b)); c != 0 {
+			return c
+		}
+	}
+	return cmp(a, b)
+}
+
+//letters of swedish and finnish, danish and norwegian after z
+var (
+	swedish = map[rune]string{'å': "z\uffff1", 'ä': "z\uffff2", 'æ': "z\uffff2", 'ö': "z\uffff3", 'ø': "z\uffff3"}
+	danish  = map[rune]string{'æ': "z\uffff1", 'ä': "z\uffff1", 'ø': "z\uffff2", 'ö': "z\uffff2", 'å': "z\uffff3"}
+)
+
+//letters of languages out of their base letters, after the alphabet or after the base letter
+var tailorings = map[string]map[rune]string{
+	"sv": swedish, "fi": swedish,
+	"da": danish, "nb": danish, "no": danish,
+	"es": {'ñ': "n\uffff"},
+	"pl": {'ą': "a\uffff", 'ć': "c\uffff", 'ę': "e\uffff", 'ł': "l\uffff", 'ń': "n\uffff", 'ó': "o\uffff", 'ś': "s\uffff", 'ź': "z\uffff1", 'ż': "z\uffff2"},
+	"cs": {'č': "c\uffff", 'ř': "r\uffff", 'š': "s\uffff", 'ž': "z\uffff"},
+}
+
+//text compared by SortFold and SortLocale, lower case, latin letters of SortLocale lose accents
+func (this *state) sortKey(str string) string {
+	if this.SortLocale == "" {
+		return strings.ToLower(str)
+	}
+
+	//language of locale such as sv-SE
+	lang := strings.ToLower(this.SortLocale)
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	tailoring := tailorings[lang]
+
+	var buf strings.Builder
+	buf.Grow(len(str))
+	for _, c := range str {
+		c = unicode.ToLower(c)
+		switch {
+		case tailoring[c] != "":
+			buf.WriteString(tailoring[c])
+		case c < utf8.RuneSelf:
+			buf.WriteRune(c)
+		case c >= 0xc0 && c < 0x100 && unicode.IsLetter(c):
+			buf.WriteString(latin1[c-0xc0])
+		case c >= 0x100 && c < 0x180 && latinExtA[c-0x100] != '?':
+			buf.WriteByte(latinExtA[c-0x100])
+		case c == 'œ' || c == 'ĳ':
+			buf.WriteString(asciiRunes[c])
+		case unicode.Is(unicode.Mn, c):
+		default:
+			buf.WriteRune(c)
+		}
+	}
+	return buf.String()
+}
+
+//compare texts with digit runs as numbers, equal numbers with more leading zeros come later
+func naturalCompare(a, b string) int {
+	zeros := 0
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if isDigit(a[i]) && isDigit(b[j]) {
+			x, y := digitRun(a[i:]), digitRun(b[j:])
+			i, j = i+len(x), j+len(y)
+			nx, ny := strings.TrimLeft(x, "0"), strings.TrimLeft(y, "0")
+			if len(nx) != len(ny) {
+				return compareInt(len(nx), len(ny))
+			}
+			if c := strings.Compare(nx, ny); c != 0 {
+				return c
+			}
+			if zeros == 0 {
+				zeros = compareInt(len(x), len(y))
+			}
+			continue
+		}
+		if a[i] != b[j] {
+			return compareByte(a[i], b[j])
+		}
+		i, j = i+1, j+1
+	}
+	if c := compareInt(len(a)-i, len(b)-j); c != 0 {
+		return c
+	}
+	return zeros
+}
+
+//leading digits of str
+func digitRun(str string) string {
+	i := 0
+	for i < len(str) && isDigit(str[i]) {
+		i++
+	}
+	return str[:i]
+}
+
+func compareByte(a, b byte) int {
+	return compareInt(int(a), int(b))
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+//compare cells of numeric columns as numbers, numbers come before other cells such as empty ones
+func (this *state) compareCells(a, b string, numeric bool) int {
+	if numeric {
+		x, _, xok := parseNumber(a)
+		y, _, yok := parseNumber(b)
+		switch {
+		case xok && yok && x < y:
+			return -1
+		case xok && yok && x > y:
+			return 1
+		case xok && !yok:
+			return -1
+		case !xok && yok:
+			return 1
+		}
+	}
+	return this.compare(a, b)
+}
+
+//sort data rows by SortColumns, columns of InferTypes numbers are compared by value, unknown names are ignored
+func (this *state) sortRows() {
+	var cols []int
+	var desc, numeric []bool
+	for _, name := range this.SortColumns {
+		reverse := strings.HasPrefix(name, "-")
+		if col := indexOf(this.names, strings.TrimPrefix(name, "-")); col >= 0 && col < this.colNum {
+			typ := this.inferType(col)
+			cols, desc = append(cols, col), append(desc, reverse)
+			numeric = append(numeric, typ == TypeInt || typ == TypeFloat)
+		}
+	}
+	if len(cols) == 0 {
+		return
+	}
+
+	head := this.headRows()
+	rows := make([]int, len(this.cells)-head)
+	for i := range rows {
+		rows[i] = head + i
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		x, y := this.cells[rows[i]], this.cells[rows[j]]
+		for k, col := range cols {
+			if c := this.compareCells(x[col], y[col], numeric[k]); c != 0 {
+				return c < 0 != desc[k]
+			}
+		}
+		return false
+	})
+
+	//move rows and their invalid cells
+	sorted := make([][]string, len(rows))
+	moved := make(map[int]int, len(rows))
+	for i, row := range rows {
+		sorted[i] = this.cells[row]
+		moved[row] = head + i
+	}
+	copy(this.cells[head:], sorted)
+	this.moveRows(func(row int) int { return moved[row] })
+}
@@ -0,0 +1,80 @@
+package table
+
+import (
+	"testing"
+)
+
+//natural order of digit runs
+func TestNaturalCompare(t *testing.T) {
+	sorted := []string{"", "file1", "file01", "file2", "file10", "v1.9", "v1.10", "v1.10.1", "v2", "x"}
+	for i := range sorted {
+		for j := range sorted {
+			if c := naturalCompare(sorted[i], sorted[j]); c != compareInt(i, j) {
+				t.Errorf("%q %q: %d", sorted[i], sorted[j], c)
+			}
+		}
+	}
+}
+
+//data rows sorted by columns
+func TestSort(t *testing.T) {
+	defer Reset()
+	plain := WithTheme(ThemePlain)
+	rows := "Name Size\nv1.10 2\nv1.9 10\nv1.9 2"
+
+	if str := Format(rows, plain, WithSort("Name", "-Size")); str != " Name   Size \n v1.10   2   \n v1.9    10  \n v1.9    2   \n" {
+		t.Errorf("lexical:\n%q", str)
+	}
+
+	//numeric columns by value, text columns by text
+	nums := "N F T\n10 1.5 -5\n100 -1.25 x\n9 1.25 -10\n_ 0 2"
+	if str := Format(nums, plain, WithSort("N")); str != "  N     F     T  \n  9   1.25   -10 \n 10    1.5   -5  \n 100  -1.25   x  \n        0     2  \n" {
+		t.Errorf("ints:\n%q", str)
+	}
+	decimals := "  N     F     T  \n 100  -1.25   x  \n        0     2  \n  9   1.25   -10 \n 10    1.5   -5  \n"
+	if str := Format(nums, plain, WithSort("F")); str != decimals {
+		t.Errorf("decimals:\n%q", str)
+	}
+
+	NaturalSort = true
+	if str := Format(rows, plain, WithSort("Name", "-Size")); str != " Name   Size \n v1.9    10  \n v1.9    2   \n v1.10   2   \n" {
+		t.Errorf("natural:\n%q", str)
+	}
+	if str := Format(nums, plain, WithSort("F")); str != decimals {
+		t.Errorf("natural decimals:\n%q", str)
+	}
+
+	//map keys of Deterministic mode
+	Deterministic = true
+	if str := Format(map[string]int{"file10": 1, "file2": 2}, plain); str != " file2   2 \n file10  1 \n" {
+		t.Errorf("map:\n%q", str)
+	}
+}
+
+//case folding and collation of languages
+func TestCollation(t *testing.T) {
+	s := newState(Defaults(), nil)
+	sorted := func(texts ...string) {
+		for i := 1; i < len(texts); i++ {
+			if s.compare(texts[i-1], texts[i]) >= 0 {
+				t.Errorf("%q: %q >= %q", s.SortLocale, texts[i-1], texts[i])
+			}
+		}
+	}
+	sorted("Banana", "apple", "Émile")
+
+	s.SortFold = true
+	sorted("Apple", "apple", "Banana", "Émile")
+
+	s.SortLocale = "fr"
+	sorted("Apple", "apple", "Émile", "Eva", "Zoë")
+
+	s.SortLocale = "sv-SE"
+	sorted("Anna", "Zoe", "Åsa", "Ärla", "Östen")
+
+	s.SortLocale = "es"
+	sorted("nube", "nunca", "ñandú", "oso")
+
+	s.SortLocale = "de"
+	sorted("Müller", "Mutter", "Straße", "Strauch")
+}
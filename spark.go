@@ -0,0 +1,75 @@
+package table
+
+import (
+	"math"
+	"reflect"
+	"strings"
+)
+
+//bars of sparkline from low to high
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+//sparkline of numeric slice or array such as ▁▂▅▇, non-numeric values, NaN and infinities are blanks,
+//can be registered by RegisterConverter or used by the spark tag
+func Sparkline(values interface{}) string {
+	v := reflect.ValueOf(values)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return ""
+	}
+
+	nums := make([]float64, v.Len())
+	low, high := math.Inf(1), math.Inf(-1)
+	for i := range nums {
+		nums[i] = toFloat(v.Index(i))
+		if math.IsInf(nums[i], 0) {
+			nums[i] = math.NaN()
+		}
+		if !math.IsNaN(nums[i]) {
+			low = math.Min(low, nums[i])
+			high = math.Max(high, nums[i])
+		}
+	}
+
+	var buf strings.Builder
+	for _, n := range nums {
+		switch {
+		case math.IsNaN(n):
+			buf.WriteByte(' ')
+		case high == low:
+			buf.WriteRune(sparkBars[0])
+		default:
+			//halves do not overflow
+			i := int((n/2 - low/2) / (high/2 - low/2) * float64(len(sparkBars)-1))
+			if i < 0 {
+				i = 0
+			} else if i > len(sparkBars)-1 {
+				i = len(sparkBars) - 1
+			}
+			buf.WriteRune(sparkBars[i])
+		}
+	}
+	return buf.String()
+}
+
+//number of value, NaN for non-numeric values
+func toFloat(v reflect.Value) float64 {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return math.NaN()
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+	return math.NaN()
+}
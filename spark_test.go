@@ -0,0 +1,39 @@
+package table
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+//sparklines of numeric slices
+func TestSparkline(t *testing.T) {
+	cases := []struct {
+		values   interface{}
+		expected string
+	}{
+		{[]int{1, 2, 3, 4, 5, 6, 7, 8}, "▁▂▃▄▅▆▇█"},
+		{[3]float64{0, math.NaN(), 1}, "▁ █"},
+		{[]uint8{5, 5}, "▁▁"},
+		{[]float64{1, math.Inf(1), 3, math.Inf(-1)}, "▁ █ "},
+		{[]float64{math.MaxFloat64, -math.MaxFloat64, 0}, "█▁▄"},
+		{[]interface{}{1, "x", 3.0}, "▁ █"},
+		{[]int{}, ""},
+		{"abc", ""},
+	}
+	for _, c := range cases {
+		if str := Sparkline(c.values); str != c.expected {
+			t.Errorf("%v: %q", c.values, str)
+		}
+	}
+
+	//spark tag
+	type Host struct {
+		Name string
+		CPU  []int `table:",,spark"`
+	}
+	str := Format([]Host{{"a", []int{0, 50, 100}}})
+	if !strings.Contains(str, "│ ▁▄█ │") {
+		t.Errorf("spark tag:\n%s", str)
+	}
+}
@@ -0,0 +1,178 @@
+package table
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+)
+
+/*
+Table written row by row
+
+Description: Column widths are decided by the first StreamSample
+	rows, then they are locked and every later row is written
+	at once. Cells of later rows wider than the locked widths are
+	cut with …, or wrapped when WrapCells is set, so a larger
+	sample gives better widths but shows the first rows later.
+	For example:
+
+	s := table.NewStream(os.Stdout)
+	for rows.Next() {
+		s.Write(row)
+	}
+	s.Close()
+*/
+type Stream struct {
+	state *state
+	w     io.Writer
+
+	//nil until widths are locked
+	board *board
+	//locked content widths
+	limits []int
+	//rows written
+	rows   int
+	err    error
+	closed bool
+}
+
+//create stream writing to w with the current global options and opts
+func NewStream(w io.Writer, opts ...Option) *Stream {
+	s := &Stream{state: newState(Defaults(), opts), w: w}
+	s.state.stream = true
+	return s
+}
+
+//create stream with the formatter's options
+func (this *Formatter) NewStream(w io.Writer, opts ...Option) *Stream {
+	s := &Stream{state: newState(this.options, opts), w: w}
+	s.state.stream = true
+	return s
+}
+
+//add row of struct fields, slice elements or a single value, the first row decides header
+func (this *Stream) Write(row interface{}) (err error) {
+	if this.err != nil {
+		return this.err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			a, ok := r.(abort)
+			if !ok {
+				panic(r)
+			}
+			err, this.err = a.err, a.err
+		}
+	}()
+
+	s := this.state
+	keys, vals, raw := s.rowCells(reflect.ValueOf(row))
+	if !s.seen {
+		s.addRow(keys)
+	}
+	s.addCells(vals, raw)
+
+	if this.board != nil {
+		return this.flush()
+	}
+	if len(s.cells)-s.headRows() >= s.StreamSample {
+		return this.lock()
+	}
+	return nil
+}
+
+//write rows which are not written and the bottom line, later calls do nothing
+func (this *Stream) Close() error {
+	if this.closed {
+		return nil
+	}
+	this.closed = true
+	if this.err != nil {
+		return this.err
+	}
+	s := this.state
+
+	//no data without border
+	if this.board == nil && s.isEmpty() && !s.EmptyBorder {
+		return this.write([]byte(s.EmptyText + s.newline()))
+	}
+
+	if this.board == nil {
+		if err := this.lock(); err != nil {
+			return err
+		}
+	} else if err := this.flush(); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	s.writeBottom(&buf, this.board)
+	return this.write(buf.Bytes())
+}
+
+//lock widths by the sampled rows and write them
+func (this *Stream) lock() error {
+	s := this.state
+	tb, colWidth := s.layout()
+	theme := s.theme()
+	for row, line := range tb {
+		if row >= s.headRows() {
+			s.starHighlights(line, colWidth)
+		}
+		if theme.Border == MarkdownBorder {
+			s.escapePipes(line, colWidth)
+		}
+	}
+	this.limits = append([]int{}, colWidth...)
+	s.columnWidth(theme, colWidth)
+	this.board = s.newBoard(theme, colWidth)
+
+	var buf bytes.Buffer
+	s.writeTop(&buf, this.board)
+	for _, line := range tb {
+		s.writeTableRow(&buf, this.board, this.rows, line, false)
+		this.rows++
+	}
+	this.drop()
+	return this.write(buf.Bytes())
+}
+
+//write rows which are not written cut to the locked widths, such as a row and its continuation rows
+func (this *Stream) flush() error {
+	s := this.state
+	if len(s.cells) == this.board.head {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, line := range s.cells[this.board.head:] {
+		s.starHighlights(line, make([]int, len(line)))
+		if this.board.theme.Border == MarkdownBorder {
+			s.escapePipes(line, make([]int, len(line)))
+		}
+		for col, val := range line {
+			if s.cellWidth(val) > this.limits[col] {
+				line[col] = s.fit(val, this.limits[col])
+			}
+		}
+		s.writeTableRow(&buf, this.board, this.rows, line, false)
+		this.rows++
+	}
+	this.drop()
+	return this.write(buf.Bytes())
+}
+
+//drop written data rows, header rows are kept
+func (this *Stream) drop() {
+	s := this.state
+	if head := s.headRows(); len(s.cells) > head {
+		s.cells = s.cells[:head]
+	}
+}
+
+//write to w and keep the first error
+func (this *Stream) write(data []byte) error {
+	if _, err := this.w.Write(data); err != nil {
+		this.err = err
+	}
+	return this.err
+}
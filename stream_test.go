@@ -0,0 +1,74 @@
+package table
+
+import (
+	"bytes"
+	"testing"
+)
+
+//widths are locked after the sample
+func TestStream(t *testing.T) {
+	type Event struct {
+		ID   int
+		Name string
+	}
+
+	var buf bytes.Buffer
+	s := NewStream(&buf, func(o *Options) { o.StreamSample = 2 })
+	s.Write(Event{1, "start"})
+	if buf.Len() != 0 {
+		t.Errorf("written before sample:\n%s", buf.String())
+	}
+	s.Write(&Event{2, "stop"})
+	s.Write(Event{3, "restart"})
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "" +
+		"┌────┬───────┐\n" +
+		"│ ID │ Name  │\n" +
+		"├────┼───────┤\n" +
+		"│ 1  │ start │\n" +
+		"├────┼───────┤\n" +
+		"│ 2  │ stop  │\n" +
+		"├────┼───────┤\n" +
+		"│ 3  │ rest… │\n" +
+		"└────┴───────┘\n"
+	if buf.String() != expected {
+		t.Errorf("stream:\n%s", buf.String())
+	}
+
+	//closing again writes nothing
+	if err := s.Close(); err != nil || buf.String() != expected {
+		t.Errorf("closed twice %v:\n%s", err, buf.String())
+	}
+
+	//continuation rows of wrapped columns after the sample
+	buf.Reset()
+	s = NewStream(&buf, WithTheme(ThemePlain), func(o *Options) {
+		o.StreamSample = 1
+		o.ColOverflowMode = OverflowWrap
+	})
+	s.Write([]string{"a", "b"})
+	s.Write([]string{"1", "2", "3", "4", "5"})
+	s.Close()
+	if buf.String() != " a  b \n 1  2 \n 3  4 \n 5    \n" {
+		t.Errorf("wrapped:\n%q", buf.String())
+	}
+
+	//rows of slices, closed before sample
+	buf.Reset()
+	s = NewStream(&buf, WithTheme(ThemeMarkdown))
+	s.Write([]string{"a", "b"})
+	s.Close()
+	if buf.String() != "|   |   |\n|---|---|\n| a | b |\n" {
+		t.Errorf("slices:\n%q", buf.String())
+	}
+
+	//errors of strict mode
+	s = NewStream(&buf, func(o *Options) { o.Strict = true })
+	s.Write([]int{1, 2})
+	if err := s.Write([]int{1}); err == nil || s.Close() != err {
+		t.Errorf("row error expected, got %v", err)
+	}
+}
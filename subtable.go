@@ -0,0 +1,65 @@
+package table
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+//map of struct slices as titled tables sorted by key, columns line up between tables
+func (this *state) runSubTables(obj interface{}) (str string, ok bool) {
+	v := reflect.ValueOf(obj)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Map {
+		return "", false
+	}
+	t := v.Type().Elem()
+	if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+		return "", false
+	}
+	if _, isStruct := this.structKeys(t.Elem()); !isStruct {
+		return "", false
+	}
+
+	//sorted titles
+	keys := v.MapKeys()
+	titles := make([]string, len(keys))
+	for i, key := range keys {
+		titles[i] = this.handleSpace(this.encodeCell(key))
+	}
+	sort.Sort(byTitle{titles, keys, this.compare})
+
+	lists := make([]interface{}, len(keys))
+	for i, key := range keys {
+		lists[i] = v.MapIndex(key).Interface()
+	}
+	tables := formatTables(this.Options, lists, nil)
+
+	var buf strings.Builder
+	style := this.theme().HeaderStyle
+	for i, table := range tables {
+		if i != 0 {
+			buf.WriteString(this.newline())
+		}
+		buf.WriteString(style.wrap(titles[i]))
+		buf.WriteString(this.newline())
+		buf.WriteString(table)
+	}
+	return buf.String(), true
+}
+
+//titles sorting keys
+type byTitle struct {
+	titles  []string
+	keys    []reflect.Value
+	compare func(a, b string) int
+}
+
+func (this byTitle) Len() int           { return len(this.titles) }
+func (this byTitle) Less(i, j int) bool { return this.compare(this.titles[i], this.titles[j]) < 0 }
+func (this byTitle) Swap(i, j int) {
+	this.titles[i], this.titles[j] = this.titles[j], this.titles[i]
+	this.keys[i], this.keys[j] = this.keys[j], this.keys[i]
+}
@@ -0,0 +1,140 @@
+package table
+
+import (
+	"bytes"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//pixels of a character cell and a line of svg text in 14px monospace font
+const (
+	svgCellWidth  = 8
+	svgLineHeight = 20
+)
+
+//svg image of table, such as dashboards and documentation
+var SVGRenderer Renderer = RendererFunc(func(t *Table, w io.Writer) error {
+	_, err := io.WriteString(w, t.FormatSVG())
+	return err
+})
+
+//format table as svg with its options
+func (this *Table) FormatSVG(opts ...Option) string {
+	return this.state(opts).formatSVG()
+}
+
+//svg of text elements and lines of border, columns are as wide as in text tables,
+//header and footer are bold, styles are not drawn
+func (this *state) formatSVG() string {
+	tb, colWidth := this.layout()
+	theme := this.theme()
+	this.columnWidth(theme, colWidth)
+	if this.omitted > 0 {
+		this.widenSpan(theme, colWidth, tb[this.elided][0])
+	}
+	b := &theme.Border
+	vertical := this.width(b.Vertical)
+
+	//left of columns and top of rows
+	xs := make([]int, len(colWidth)+1)
+	for col, w := range colWidth {
+		xs[col+1] = xs[col] + w*svgCellWidth
+		if col != len(colWidth)-1 {
+			xs[col+1] += vertical * svgCellWidth
+		}
+	}
+	ys := make([]int, len(tb)+1)
+	for row, line := range tb {
+		height := 1
+		for _, val := range line {
+			if n := strings.Count(val, "\n") + 1; n > height {
+				height = n
+			}
+		}
+		ys[row+1] = ys[row] + height*svgLineHeight
+	}
+	width, height := xs[len(xs)-1], ys[len(ys)-1]
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	buf.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" width="` + strconv.Itoa(width) + `" height="` + strconv.Itoa(height) +
+		`" font-family="monospace" font-size="14">` + "\n")
+	buf.WriteString(`<rect width="100%" height="100%" fill="white"/>` + "\n")
+
+	//lines of border
+	head, foot := this.headRows(), -1
+	if this.footer {
+		foot = len(tb) - 1
+	}
+	if b.Horizontal != "" {
+		for row := 0; row <= len(tb); row++ {
+			edge := row == 0 || row == len(tb)
+			if edge && b.Frame || !edge && (row == head || row > head && b.RowLines || row == foot) {
+				writeSVGLine(buf, 0, ys[row], width, ys[row])
+			}
+		}
+	}
+	if b.Vertical != "" {
+		for col := 0; col <= len(colWidth); col++ {
+			edge := col == 0 || col == len(colWidth)
+			if !edge || b.Sides {
+				x := xs[col] - vertical*svgCellWidth/2
+				if edge {
+					x = xs[col]
+				}
+				writeSVGLine(buf, x, 0, x, height)
+			}
+		}
+	}
+
+	//cells
+	for row, line := range tb {
+		if row%checkBatch == 0 {
+			this.check()
+		}
+		weight := ""
+		if row < head || row == foot {
+			weight = ` font-weight="bold"`
+		}
+		if this.isElided(row) {
+			this.writeSVGText(buf, line[0], width/2, ys[row], "middle", weight)
+			continue
+		}
+		for col, val := range line {
+			x, anchor := (xs[col]+xs[col+1])/2, "middle"
+			if col != len(line)-1 {
+				x -= vertical * svgCellWidth / 2
+			}
+			switch this.align(theme, col) {
+			case AlignLeft:
+				x, anchor = xs[col]+theme.Padding*svgCellWidth, "start"
+			case AlignRight:
+				x, anchor = xs[col]+colWidth[col]*svgCellWidth-theme.Padding*svgCellWidth, "end"
+			}
+			this.writeSVGText(buf, val, x, ys[row], anchor, weight)
+		}
+	}
+	buf.WriteString("</svg>\n")
+	return buf.String()
+}
+
+//write line of border
+func writeSVGLine(buf *bytes.Buffer, x1, y1, x2, y2 int) {
+	buf.WriteString(`<line x1="` + strconv.Itoa(x1) + `" y1="` + strconv.Itoa(y1) + `" x2="` + strconv.Itoa(x2) + `" y2="` + strconv.Itoa(y2) +
+		`" stroke="black"/>` + "\n")
+}
+
+//write text elements of the lines of cell from top
+func (this *state) writeSVGText(buf *bytes.Buffer, val string, x, top int, anchor, weight string) {
+	for i, text := range strings.Split(val, "\n") {
+		if text == "" {
+			continue
+		}
+		y := top + (i+1)*svgLineHeight - svgLineHeight/4
+		buf.WriteString(`<text x="` + strconv.Itoa(x) + `" y="` + strconv.Itoa(y) + `" text-anchor="` + anchor + `"` + weight +
+			` xml:space="preserve">` + html.EscapeString(text) + "</text>\n")
+	}
+}
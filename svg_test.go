@@ -0,0 +1,27 @@
+package table
+
+import (
+	"testing"
+)
+
+//text elements at column positions with border lines
+func TestFormatSVG(t *testing.T) {
+	tb, _ := Encode("Name Size\na<b 1", WithTheme(ThemeCompact))
+	expected := "" +
+		`<svg xmlns="http://www.w3.org/2000/svg" width="104" height="40" font-family="monospace" font-size="14">` + "\n" +
+		`<rect width="100%" height="100%" fill="white"/>` + "\n" +
+		`<line x1="0" y1="20" x2="104" y2="20" stroke="black"/>` + "\n" +
+		`<line x1="52" y1="0" x2="52" y2="40" stroke="black"/>` + "\n" +
+		`<text x="8" y="15" text-anchor="start" font-weight="bold" xml:space="preserve">Name</text>` + "\n" +
+		`<text x="64" y="15" text-anchor="start" font-weight="bold" xml:space="preserve">Size</text>` + "\n" +
+		`<text x="8" y="35" text-anchor="start" xml:space="preserve">a&lt;b</text>` + "\n" +
+		`<text x="64" y="35" text-anchor="start" xml:space="preserve">1</text>` + "\n" +
+		"</svg>\n"
+	if str := tb.FormatSVG(); str != expected {
+		t.Errorf("svg:\n%s", str)
+	}
+
+	if _, ok := RendererOf("svg"); !ok {
+		t.Errorf("svg renderer is not registered")
+	}
+}
@@ -1,17 +1,13 @@
 package table
 
 import (
-	"bytes"
-	"fmt"
-	"reflect"
-	"runtime"
-	"strconv"
-	"strings"
-	"unicode"
-	"unicode/utf8"
+	"context"
+	"io"
+	"os"
 )
 
-//option config parameters
+//option config parameters, they are the defaults of every Format call,
+//do not change them while other goroutines are formatting, use Formatter instead
 var (
 	//separate rows
 	RowSeparator string = "\n"
@@ -45,6 +41,103 @@ var (
 
 	//whether ignore empty header when all header fields are placeholder
 	IgnoreEmptyHeader bool = true
+
+	//what to show for nil pointer, interface, map and slice, empty string means Placeholder
+	NilText string = ""
+
+	//cells of East Asian ambiguous characters such as Greek, Cyrillic and box drawing, 1 or 2
+	AmbiguousWidth int = 1
+
+	//expand tab to the next multiple of TabWidth cells, 0 means replace it with SpaceAlt
+	TabWidth int = 0
+
+	//show control characters as escapes such as \n and \x07 instead of SpaceAlt
+	EscapeControl bool = false
+
+	//wrap link cells in OSC 8 terminal hyperlinks, otherwise show them as plain text
+	Hyperlinks bool = false
+
+	//what to show instead of a table without data rows, empty string means the table as is
+	EmptyText string = ""
+
+	//draw border around EmptyText
+	EmptyBorder bool = true
+
+	//reject rows longer or shorter than header, FormatContext returns *RowError and Format shows it
+	Strict bool = false
+
+	//how to handle more columns than header, OverflowAuto means ColOverflow decides
+	ColOverflowMode OverflowMode = OverflowAuto
+
+	//rows of Stream deciding column widths, cells of later rows are cut to the widths
+	StreamSample int = 100
+
+	//digits after the point of big.Float and big.Rat, -1 means their String methods
+	BigPrecision int = -1
+
+	//separate thousands of big.Int, big.Float and big.Rat, such as ","
+	BigGrouping string = ""
+
+	//texts of true and false, such as BoolCheck, empty style means true and false
+	BoolText BoolStyle = BoolStyle{}
+
+	//show []byte and strings with non-printable bytes as BinaryHex or BinaryBase64
+	BinaryEncoding BinaryMode = BinaryRaw
+
+	//bytes of binary data shown by BinaryEncoding, 0 means all
+	BinaryMaxBytes int = 32
+
+	//cut long cells in the middle keeping start and end, such as /usr/…/file.go, instead of cutting the end
+	MiddleEllipsis bool = false
+
+	//hide header and unit rows with the line under them, header still names columns for options
+	NoHeader bool = false
+
+	//format map of struct slices as one titled table per key instead of one table with a key column
+	SubTables bool = false
+
+	//drop theme styles, heatmap, change marks and hyperlinks, such as for output to files
+	NoColor bool = false
+
+	//use ascii borders without colors for consoles which can not show box drawing and escape sequences
+	LegacyConsole bool = false
+
+	//rounding of prec tags and Precisions
+	Rounding RoundingMode = RoundHalfEven
+
+	//show negative numbers in parentheses such as (12.50) like financial reports
+	Accounting bool = false
+
+	//style of negative numbers such as "31" for red, empty style means no style
+	NegativeStyle Style = ""
+
+	//byte-identical output across runs and machines, addresses are hidden, NO_COLOR, FORCE_COLOR and terminals are ignored
+	Deterministic bool = false
+
+	//drop terminal escape sequences, bidi and other control characters of cells, such as user-supplied content, hyperlinks are shown as text
+	Sanitize bool = false
+
+	//prefix CSV and TSV cells starting with = + - @ with an apostrophe so that spreadsheets do not run them, numbers are kept
+	EscapeFormulas bool = false
+
+	//end of output lines, such as "\r\n" for Windows tools, empty string means "\n"
+	LineEnding string = "\n"
+
+	//transliterate cells to ascii such as é to e and draw ascii borders for 7-bit output such as serial consoles, characters without approximation become ?
+	ASCIIOnly bool = false
+
+	//format of strings, InputText splits them by RowSeparator and ColumnSeparator,
+	//InputAuto detects json, logfmt, tsv and csv unless separators are set
+	Input InputFormat = InputText
+
+	//compare digit runs by value when sorting rows, map keys and sub-table titles, such as file2 before file10 and v1.9 before v1.10
+	NaturalSort bool = false
+
+	//compare sorted texts ignoring case, such as apple before Banana
+	SortFold bool = false
+
+	//language of sorted texts such as "de" or "sv", case and accents are ignored and letters such as ñ and å take their place in the alphabet, empty string means byte order
+	SortLocale string = ""
 )
 
 //reset all the configs to default, if change the config, go defer it makes good
@@ -60,6 +153,38 @@ func Reset() {
 	OverFlowSeparator = " "
 	CenterFilling = ' '
 	IgnoreEmptyHeader = true
+	NilText = ""
+	AmbiguousWidth = 1
+	TabWidth = 0
+	EscapeControl = false
+	Hyperlinks = false
+	EmptyText = ""
+	EmptyBorder = true
+	Strict = false
+	ColOverflowMode = OverflowAuto
+	StreamSample = 100
+	BigPrecision = -1
+	BigGrouping = ""
+	BoolText = BoolStyle{}
+	BinaryEncoding = BinaryRaw
+	BinaryMaxBytes = 32
+	MiddleEllipsis = false
+	NoHeader = false
+	SubTables = false
+	NoColor = false
+	LegacyConsole = false
+	Rounding = RoundHalfEven
+	Accounting = false
+	NegativeStyle = ""
+	Deterministic = false
+	Sanitize = false
+	EscapeFormulas = false
+	LineEnding = "\n"
+	ASCIIOnly = false
+	Input = InputText
+	NaturalSort = false
+	SortFold = false
+	SortLocale = ""
 }
 
 /*
@@ -86,10 +211,29 @@ Description: Any type which implements this interface
 	}
 
 	The common style of table tag defined in the struct is:
-	`table : [name] [,type] [,nolist]`
+	`table : [name] [,type|method:name] [,nolist] [,raw] [,link] [,spark] [,unit=unit] [,empty=text] [,pad=rune]
+		[,width=n] [,maxwidth=n] [,json[=indent]] [,hidewhenempty] [,prec=n] [,notation=sci|plain]
+		[,footer=sum|avg|count|last]`
 	1. 'name' renames the field in the table, if 'name' is '-', it means the field is totoally ignored
 	2. 'type' allows user define the convertion behavior of the field
 	3. 'nolist' defines whether the field appears when format object list or map
+	4. 'raw' keeps the field's content as is, line breaks make multi-line cells,
+		fields of RawString type are always raw
+	5. 'link' makes the field's value a hyperlink to itself, converted text is shown when 'type' is set
+	6. 'method:name' shows the first result of the struct's niladic method instead of the field's value,
+		such as `table:"Status,method:StatusText"`, pointer receivers are supported
+	7. 'spark' shows the field's numeric slice as a sparkline such as ▁▂▅▇
+	8. 'unit=unit' shows the unit under the column's header, such as `table:"Latency,,unit=ms"`
+	9. 'empty=text' shows text in the column's empty and nil cells instead of Placeholder, such as `table:",,empty=n/a"`
+	10. 'pad=rune' fills the column's data cells with rune instead of CenterFilling, such as `table:",,pad=."`
+	11. 'width=n' and 'maxwidth=n' make the column n cells wide or at most n cells wide, longer cells are cut with …
+	12. 'json' compacts the field's json string or []byte, 'json=indent' shows it indented in multi-line cells,
+		invalid json is shown as is, add 'maxwidth=n' to cut long json
+	13. 'hidewhenempty' hides the column when all of its data cells are empty
+	14. 'prec=n' shows the field's float with n digits after the point, rounded by Rounding
+	15. 'notation=sci' always shows the field's float with exponent such as 1.5e-09, 'notation=plain' never does
+	16. 'footer=sum' adds a footer row under data rows with the column's total, 'avg' shows the average,
+		'count' the number of non-empty cells and 'last' the last non-empty cell
 
 Parameters:
 	field: Represents any field's value in struct
@@ -100,597 +244,70 @@ type Convertable interface {
 	Convert(field interface{}, typeStr string) string
 }
 
-//raw string type, do not tokenize string's content
-type RawString string
-
-//the format API
-func Format(obj interface{}) string {
-	return format(encode(obj))
-}
-
-//quick print
-func Print(obj interface{}) {
-	fmt.Print(Format(obj))
-}
-
-//encode object, ignore panics
-func encode(obj interface{}) (str string) {
-	//ignore all the panic
-	defer func() {
-		if r := recover(); r != nil {
-			str = createEmptyHeader(1) + createRow(fmt.Sprint(r))
-		}
-	}()
-
-	v := reflect.ValueOf(obj)
-
-	return encodeAny(v)
-}
-
-//encode any type
-func encodeAny(v reflect.Value) (str string) {
-	switch v.Kind() {
-	case reflect.Ptr, reflect.Interface:
-		str = encodeAny(v.Elem())
-	case reflect.String:
-		str = encodeString(v)
-	case reflect.Array, reflect.Slice:
-		str = encodeList(v)
-	case reflect.Struct:
-		str = encodeStruct(v)
-	case reflect.Map:
-		str = encodeMap(v)
-	case reflect.Func:
-		str = encodeFunc(v)
-	default:
-		_, str = encodePlain(v)
-	}
-
-	return str
-}
-
-//raw string
-func encodeRawString(v reflect.Value) (str string) {
-	var buf bytes.Buffer
-	obj := v.Interface()
-
-	if o, ok := obj.(RawString); ok {
-		buf.WriteString(createEmptyHeader(1))
-		buf.WriteString(createRow(string(o)))
-	}
-
-	return buf.String()
-}
-
-//string type, classic format type
-func encodeString(v reflect.Value) (str string) {
-	var buf bytes.Buffer
-	if v.Kind() != reflect.String {
-		return buf.String()
-	}
-
-	obj := v.Interface()
-
-	//raw string
-	if _, ok := obj.(RawString); ok {
-		return encodeRawString(v)
-	}
-
-	//normal string
-	if o, ok := obj.(string); ok {
-		buf.WriteString(createRow(o))
-	}
-
-	return buf.String()
-}
-
-//function type, get the function name
-func encodePlainFunc(v reflect.Value) (str string) {
-	var buf bytes.Buffer
-
-	if v.Kind() != reflect.Func {
-		return buf.String()
-	}
-
-	buf.WriteString(createRow(runtime.FuncForPC(v.Pointer()).Name()))
-
-	return buf.String()
-}
-
-//function type, get the function name
-func encodeFunc(v reflect.Value) (str string) {
-	var buf bytes.Buffer
-
-	if v.Kind() != reflect.Func {
-		return buf.String()
-	}
-
-	buf.WriteString(createEmptyHeader(1))
-	buf.WriteString(encodePlainFunc(v))
-
-	return buf.String()
-}
-
-//base types
-func encodePlain(v reflect.Value) (key, str string) {
-	key = Placeholder
-	switch v.Kind() {
-	case reflect.Invalid:
-
-	case reflect.Ptr, reflect.Interface:
-		key, str = encodePlain(v.Elem())
-	case reflect.Struct:
-		key, str = encodePlainStruct(v)
-	case reflect.Func:
-		str = encodePlainFunc(v)
-	default:
-		str = fmt.Sprint(v.Interface())
-	}
-
-	return key, str
-}
-
-//map type
-func encodeMap(v reflect.Value) (str string) {
-	var buf bytes.Buffer
-
-	if v.Kind() != reflect.Map {
-		return buf.String()
-	}
-
-	keys := v.MapKeys()
-	for i, key := range keys {
-		value := v.MapIndex(key)
-
-		k1, v1 := encodePlain(key)
-		k2, v2 := encodePlain(value)
-
-		if i == 0 {
-			buf.WriteString(createRow(k1, k2))
-		}
-		buf.WriteString(createRow(v1, v2))
-	}
-	return buf.String()
-}
-
-//array, slice type
-func encodeList(v reflect.Value) (str string) {
-	var buf bytes.Buffer
-
-	if v.Kind() != reflect.Array && v.Kind() != reflect.Slice {
-		return buf.String()
-	}
-
-	//format list
-	for i := 0; i < v.Len(); i++ {
-		key, val := encodePlain(v.Index(i))
-
-		if i == 0 {
-			buf.WriteString(createRow(Placeholder, key))
-		}
-		buf.WriteString(createRow(strconv.Itoa(i+1), val))
-	}
-
-	return buf.String()
-}
-
-//return key string and value string
-func encodePlainStruct(v reflect.Value) (string, string) {
-	_, _, keys, vals := processStruct(v)
-
-	if len(keys) == 0 {
-		keys = []string{Placeholder}
-		vals = []string{fmt.Sprint(v.Interface())}
-	}
-
-	return createRow(keys...), createRow(vals...)
-}
-
-//struct type
-func encodeStruct(v reflect.Value) (str string) {
-	var buf bytes.Buffer
-
-	keys, vals, _, _ := processStruct(v)
-	if len(keys) == 0 {
-		return fmt.Sprint(v.Interface())
-	}
-
-	buf.WriteString(createEmptyHeader(2))
-
-	for i := 0; i < len(keys); i++ {
-		buf.WriteString(createRow(keys[i], vals[i]))
-	}
-
-	return buf.String()
-}
-
-//process struct, return objfmt fields and listfmt fields
-func processStruct(v reflect.Value) (detKeys, detVals, absKeys, absVals []string) {
-	detKeys = []string{}
-	detVals = []string{}
-	absKeys = []string{}
-	absVals = []string{}
-
-	obj := v.Interface()
-
-	if v.Kind() != reflect.Struct {
-		return detKeys, detVals, absKeys, absVals
-	}
-
-	//struct fields
-	t := v.Type()
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-
-		//get field name and value
-		name := field.Name
-		value := v.FieldByName(field.Name)
-		val := value.Interface()
-
-		tag := field.Tag.Get("table")
-		nameTag, typeTag, listTag := parseTag(tag)
-
-		//name tag
-		if nameTag == "-" {
-			continue
-		} else if nameTag != "" {
-			name = nameTag
-		}
-
-		//type tag
-		if o, ok := obj.(Convertable); ok && typeTag != "" {
-			val = o.Convert(val, typeTag)
-		}
-
-		//list tag
-		valStr := fmt.Sprintf("%v", val)
-		detKeys = append(detKeys, name)
-		detVals = append(detVals, valStr)
-		if listTag != "nolist" {
-			absKeys = append(absKeys, name)
-			absVals = append(absVals, valStr)
-		}
-	}
-	return detKeys, detVals, absKeys, absVals
-}
-
-//parse tag, process tag: `table:"-|<newName>[,<newType>][,<nolist>]"`
-func parseTag(tag string) (nameTag, typeTag, listTag string) {
-	//tokenize
-	values := strings.Split(tag, ",")
-	num := len(values)
-	if num > 0 {
-		nameTag = values[0]
-	}
-	if num > 1 {
-		typeTag = values[1]
-	}
-	if num > 2 {
-		listTag = values[2]
-	}
-
-	return nameTag, typeTag, listTag
-}
-
-//merge placehold woth col sep
-func createEmptyHeader(colNum int) string {
-	fields := make([]string, colNum)
-	for i, _ := range fields {
-		fields[i] = Placeholder
-	}
-	return createRow(fields...)
+//field of struct row converted by ConvertableV2
+type Conversion struct {
+	//header name and type tag of field
+	Field string
+	Type  string
+	//data row from 0
+	Index int
+	Value interface{}
+	//the whole struct of row
+	Row interface{}
 }
 
-//merge fields with col sep
-func createRow(fields ...string) string {
-	sep := " "
-	if ColumnSeparator != "" {
-		sep = ColumnSeparator
-	}
-
-	var buf bytes.Buffer
-	for i, field := range fields {
-		field = strings.TrimSuffix(field, RowSeparator)
-		if i != 0 {
-			buf.WriteString(sep)
-		}
-		buf.WriteString(field)
-	}
-	buf.WriteString(RowSeparator)
-
-	return buf.String()
+//convert interface knowing the row, fields with type tag are converted,
+//an error aborts formatting, Format shows it and FormatContext returns *ConvertError, for example:
+//
+//	func (this Price) Convert(c table.Conversion) (string, error) {
+//		return fmt.Sprintf("%.2f %s", c.Value, this.Currency), nil
+//	}
+type ConvertableV2 interface {
+	Convert(c Conversion) (string, error)
 }
 
-//table format
-func format(data string) string {
-	//convert string to table
-	tb := preProcess(data)
-
-	//print table
-	if UseBoard {
-		return boardFormat(tb)
-	} else {
-		return simpleFormat(tb)
-	}
-}
-
-//utf8 table characters
-const (
-	hrLine = "─"
-	vtLine = "│"
-
-	topLeft   = "┌"
-	topCenter = "┬"
-	topRight  = "┐"
-
-	middleLeft   = "├"
-	middleCenter = "┼"
-	middleRight  = "┤"
-
-	bottomLeft   = "└"
-	bottomCenter = "┴"
-	bottomRight  = "┘"
-)
-
-//format with board
-func boardFormat(tb [][]string) string {
-	if len(tb) == 0 {
-		tb = [][]string{{string(CenterFilling) + BlankFillingForHeader + string(CenterFilling)}}
-	}
-	//table attributes
-	rowNum := len(tb)*2 + 1
-	colNum := len(tb[0])*2 + 1
-	colWidth := make([]int, colNum)
-	for i, _ := range tb[0] {
-		colWidth[i] = width(tb[0][i])
-	}
-
-	//init fill as --- ...
-	fill := make([]string, colNum/2)
-	for i, _ := range fill {
-		fill[i] = strings.Repeat(hrLine, colWidth[i])
-	}
-
-	//init top ┌───┬───┐
-	topLine := initLine(topLeft, topCenter, topRight, fill)
-
-	//init middle ├───┼───┤
-	middleLine := initLine(middleLeft, middleCenter, middleRight, fill)
-
-	//init bottom └───┴───┘
-	bottomLine := initLine(bottomLeft, bottomCenter, bottomRight, fill)
-
-	//create board table
-	table := make([][]string, rowNum)
-	for i, _ := range table {
-		switch {
-		case i == 0:
-			table[i] = topLine
-		case i == rowNum-1:
-			table[i] = bottomLine
-		case i%2 == 0:
-			table[i] = middleLine
-		default:
-			table[i] = initLine(vtLine, vtLine, vtLine, tb[i/2])
-		}
-	}
-
-	//output table
-	var buf bytes.Buffer
-	for _, line := range table {
-		for _, val := range line {
-			buf.WriteString(val)
-		}
-		buf.WriteString("\n")
-	}
-
-	return buf.String()
+//raw string type, do not tokenize string's content
+type RawString string
 
+//the format API, global options are read once, then opts are applied
+func Format(obj interface{}, opts ...Option) string {
+	return newState(Defaults(), opts).run(obj)
 }
 
-//format without board
-func simpleFormat(tb [][]string) string {
-	if len(tb) == 0 {
-		tb = [][]string{{string(CenterFilling) + BlankFillingForHeader + string(CenterFilling)}}
-	}
-	//out put table
-	var buf bytes.Buffer
-	for _, line := range tb {
-		for _, val := range line {
-			buf.WriteString(val)
-		}
-		buf.WriteString("\n")
-	}
-
-	return buf.String()
+//append format of obj to dst and return the extended buffer, like time.AppendFormat,
+//the table is written into dst without an intermediate string
+func AppendFormat(dst []byte, obj interface{}, opts ...Option) []byte {
+	return newState(Defaults(), opts).appendRun(dst, obj)
 }
 
-//split str and filt empty line
-func getLines(str string) []string {
-	var lines []string
-	if RowSeparator == "" {
-		lines = strings.Fields(str)
-	} else {
-		lines = strings.Split(str, RowSeparator)
-	}
-
-	//filt empty string
-	ret := []string{}
-	for _, f := range lines {
-		if len(f) > 0 {
-			ret = append(ret, f)
-		}
-	}
-	return ret
+//the format API, abort between row batches when ctx is done
+func FormatContext(ctx context.Context, obj interface{}, opts ...Option) (string, error) {
+	return newState(Defaults(), opts).runContext(ctx, obj)
 }
 
-//split line and filt empty elements
-func getFields(line string) []string {
-	var fields []string
-	if ColumnSeparator == "" {
-		fields = strings.Fields(line)
-	} else {
-		fields = strings.Split(line, ColumnSeparator)
-	}
-
-	//filt empty string
-	ret := []string{}
-	for _, f := range fields {
-		if len(f) > 0 {
-			ret = append(ret, f)
-		}
-	}
-	return ret
+//format as html table, TableAttrs, RowAttrs and ColumnAttrs add attributes for stylesheets
+func FormatHTML(obj interface{}, opts ...Option) string {
+	return newState(Defaults(), opts).runHTML(obj)
 }
 
-//change all the space character (\t \n _ \b) to space
-func handleSpace(str string) string {
-	arr := make([]rune, utf8.RuneCountInString(str))
-	index := 0
-	for _, c := range str {
-		if unicode.IsSpace(c) && c != ' ' {
-			c = rune(SpaceAlt)
-		}
-		arr[index] = c
-		index++
-	}
-	return string(arr)
+//format obj with the cells changed since prev styled, such as a refreshed dashboard,
+//prev and obj should have the same shape, new rows and columns are changed
+func FormatChanges(prev, obj interface{}, opts ...Option) string {
+	return newState(Defaults(), opts).runChanges(prev, obj)
 }
 
-//how long is string in screen, Chinese chararter is 2 length
-func width(str string) int {
-	sum := 0
-	for _, c := range str {
-		if utf8.RuneLen(c) > 1 {
-			sum += 2
-		} else {
-			sum++
-		}
-	}
-	return sum
+//format tables with shared column widths, so that columns line up when they are printed in sequence,
+//such as one table per group, columns are matched by index
+func FormatTables(objs []interface{}, opts ...Option) []string {
+	return formatTables(Defaults(), objs, opts)
 }
 
-//convert string to 2-D slice
-func preProcess(data string) [][]string {
-	//get non-blank lines
-	lines := []string{}
-	//for _, line := range strings.Split(data, RowSeparator) {
-	for _, line := range getLines(data) {
-		if len(getFields(line)) != 0 {
-			lines = append(lines, line)
-		}
-	}
-
-	rowNum := len(lines)
-
-	//handle empty table
-	if rowNum == 0 {
-		//use place holder to represent a empty table
-		return [][]string{{string(CenterFilling) + BlankFillingForHeader + string(CenterFilling)}}
-	}
-
-	//get columns
-	colNum := len(getFields(lines[0]))
-	//max width of each column
-	colWidth := make([]int, colNum)
-
-	//process empty header
-	if IgnoreEmptyHeader {
-		header := getFields(lines[0])
-		ignore := true
-		for _, val := range header {
-			if val != Placeholder {
-				ignore = false
-				break
-			}
-		}
-		if ignore {
-			lines = lines[1:]
-			rowNum--
-		}
-	}
-
-	tb := make([][]string, rowNum)
-	for row, line := range lines {
-		tb[row] = make([]string, colNum)
-
-		//fillings
-		filling := BlankFilling
-		if row == 0 {
-			filling = BlankFillingForHeader
-		}
-
-		//init row as blank filling
-		for index, _ := range tb[row] {
-			tb[row][index] = filling
-		}
-
-		//get fields
-		fields := getFields(line)
-		for col, val := range fields {
-			//handle placeholder
-			if val == Placeholder {
-				val = filling
-			}
-
-			//handle column overflow
-			if col >= colNum {
-				if ColOverflow {
-					col = colNum - 1
-					val = tb[row][col] + OverFlowSeparator + val
-				} else {
-					//discard more cols
-					break
-				}
-			}
-			tb[row][col] = handleSpace(val)
-		}
-	}
-
-	//calcu max width, extend colwidth + 2 to store blank
-	for col := 0; col < colNum; col++ {
-		for row := 0; row < rowNum; row++ {
-			val := tb[row][col]
-			size := width(val)
-			if size > colWidth[col] {
-				colWidth[col] = size
-			}
-		}
-		colWidth[col] += 2
-	}
-
-	//middle value with blank
-	cfill := string(CenterFilling)
-	for row, line := range tb {
-		for col, val := range line {
-			size := width(val)
-			left := (colWidth[col] - size) / 2
-			right := colWidth[col] - size - left
-			tb[row][col] = strings.Repeat(cfill, left) + val + strings.Repeat(cfill, right)
-		}
-	}
-
-	return tb
-
+//format obj to w, rows are written in chunks instead of buffering the whole table, errors of w are returned
+func Fprint(w io.Writer, obj interface{}, opts ...Option) error {
+	return newState(Defaults(), opts).runWriter(w, obj)
 }
 
-//form table line
-func initLine(left, center, right string, fill []string) []string {
-	colNum := len(fill)*2 + 1
-	line := make([]string, colNum)
-	for i, _ := range line {
-		tmp := ""
-		switch {
-		case i == 0:
-			tmp = left
-		case i == colNum-1:
-			tmp = right
-		case i%2 == 0:
-			tmp = center
-		default:
-			tmp = fill[i/2]
-		}
-		line[i] = tmp
-	}
-	return line
+//quick print, colors are dropped when stdout is not a terminal
+func Print(obj interface{}, opts ...Option) {
+	Fprint(os.Stdout, obj, append([]Option{WithOutput(os.Stdout, nil)}, opts...)...)
 }
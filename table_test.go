@@ -1,11 +1,24 @@
 package table
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
 
+//colors of tests do not depend on NO_COLOR and FORCE_COLOR of the environment
+func TestMain(m *testing.M) {
+	os.Unsetenv("NO_COLOR")
+	os.Unsetenv("FORCE_COLOR")
+	os.Exit(m.Run())
+}
+
 //object format definition
 type Obj struct {
 	Key     string `table:"Name"`
@@ -94,3 +107,651 @@ func TestListObj(t *testing.T) {
 	n := &My{"hello", 22}
 	fmt.Println(Format(map[*My]*Obj{m: o, n: o}))
 }
+
+//nil values
+func TestNilText(t *testing.T) {
+	type Node struct {
+		Name  string
+		Next  *Node
+		Attrs map[string]string
+		Tags  []string
+		Any   interface{}
+	}
+	n := Node{Name: "head"}
+
+	str := Format(n)
+	fmt.Print(str)
+	if strings.Contains(str, "<nil>") {
+		t.Errorf("nil field leaks <nil>:\n%s", str)
+	}
+
+	NilText = "null"
+	defer Reset()
+	str = Format(map[string]*Node{"1": nil})
+	fmt.Print(str)
+	if !strings.Contains(str, "null") {
+		t.Errorf("NilText not applied:\n%s", str)
+	}
+}
+
+//struct list
+func TestStructList(t *testing.T) {
+	type Host struct {
+		Name  string
+		Port  int
+		Debug bool `table:",,nolist"`
+	}
+	list := []*Host{{"alpha", 80, true}, nil, {"beta", 8080, false}}
+
+	str := Format(list)
+	fmt.Print(str)
+	if strings.Contains(str, "Debug") {
+		t.Errorf("nolist field is listed:\n%s", str)
+	}
+	if !strings.Contains(str, "│ 3 │ beta  │ 8080 │") {
+		t.Errorf("struct element not expanded:\n%s", str)
+	}
+}
+
+//struct map
+func TestStructMap(t *testing.T) {
+	type Usage struct {
+		CPU    float64
+		Memory string
+	}
+	m := map[string]Usage{"web": {0.5, "512M"}}
+
+	str := Format(m)
+	fmt.Print(str)
+	if !strings.Contains(str, "│ web │ 0.5 │  512M  │") {
+		t.Errorf("struct value not expanded:\n%s", str)
+	}
+}
+
+//map of list and list of map
+func TestSliceMapAndMapList(t *testing.T) {
+	sections := map[string][]int{"odd": {1, 3, 5}}
+	str := Format(sections)
+	fmt.Print(str)
+	if !strings.Contains(str, "│ odd │ 1 │ 1 │") || !strings.Contains(str, "│     │ 3 │ 5 │") {
+		t.Errorf("map of list not sectioned:\n%s", str)
+	}
+
+	sections = map[string][]int{"d": {1}, "b": {2}, "e": {3}, "a": {4}, "c": {5}}
+	str = Format(sections)
+	if a, b, e := strings.Index(str, "│ a │"), strings.Index(str, "│ b │"), strings.Index(str, "│ e │"); a < 0 || a > b || b > e {
+		t.Errorf("map rows not sorted by default:\n%s", str)
+	}
+
+	rows := []map[string]string{{"host": "a", "zone": "x"}, {"host": "b", "rack": "7"}}
+	str = Format(rows)
+	fmt.Print(str)
+	if !strings.Contains(str, "│   │ host │ rack │ zone │") || !strings.Contains(str, "│ 2 │  b   │  7   │      │") {
+		t.Errorf("list of map not merged by keys:\n%s", str)
+	}
+}
+
+//map of map
+func TestMatrix(t *testing.T) {
+	m := map[string]map[string]int{
+		"cat": {"cat": 5, "dog": 1},
+		"dog": {"dog": 7, "fox": 2},
+	}
+	str := Format(m)
+	fmt.Print(str)
+	if !strings.Contains(str, "│     │ cat │ dog │ fox │") || !strings.Contains(str, "│ dog │     │  7  │  2  │") {
+		t.Errorf("nested map is not a matrix:\n%s", str)
+	}
+
+	m = map[string]map[string]int{"fox": {"cat": 1}, "ant": {"cat": 2}, "cow": {"cat": 3}}
+	str = Format(m)
+	if a, c, f := strings.Index(str, "│ ant │"), strings.Index(str, "│ cow │"), strings.Index(str, "│ fox │"); a < 0 || a > c || c > f {
+		t.Errorf("matrix rows not sorted by default:\n%s", str)
+	}
+}
+
+//separators inside encoded data are kept in cells
+func TestSeparatorInData(t *testing.T) {
+	ColumnSeparator = ","
+	defer Reset()
+
+	type Item struct {
+		Name string
+		Tags string
+	}
+	str := Format([]Item{{"a b", "x,y"}})
+	fmt.Print(str)
+	if !strings.Contains(str, "│ 1 │ a b  │ x,y  │") {
+		t.Errorf("separator split a cell:\n%s", str)
+	}
+}
+
+//large struct list
+func BenchmarkFormatStructList(b *testing.B) {
+	type Row struct {
+		ID    int
+		Name  string
+		Score float64
+	}
+	rows := make([]Row, 100000)
+	for i := range rows {
+		rows[i] = Row{i, fmt.Sprintf("name-%d", i), float64(i) / 3}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Format(rows)
+	}
+}
+
+//large classic string
+func BenchmarkFormatString(b *testing.B) {
+	var sb strings.Builder
+	sb.WriteString("ID Name Score\n")
+	for i := 0; i < 100000; i++ {
+		fmt.Fprintf(&sb, "%d name-%d %d\n", i, i, i*7)
+	}
+	str := sb.String()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Format(str)
+	}
+}
+
+//unexported fields are skipped, cached type info is reused
+func TestStructCache(t *testing.T) {
+	type Secret struct {
+		Name  string
+		token string
+	}
+	list := []Secret{{"a", "x"}, {"b", "y"}}
+
+	for i := 0; i < 2; i++ {
+		str := Format(list)
+		if strings.Contains(str, "token") || !strings.Contains(str, "│ 2 │  b   │") {
+			t.Errorf("unexpected struct list:\n%s", str)
+		}
+	}
+}
+
+//tab expansion
+func TestTabWidth(t *testing.T) {
+	type Line struct {
+		Text string
+	}
+	lines := []Line{{"a\tb"}, {"你\tc"}, {"abcd\te"}}
+
+	str := Format(lines, func(o *Options) { o.TabWidth = 4 })
+	fmt.Print(str)
+	for _, expect := range []string{"a   b", "你  c", "abcd    e"} {
+		if !strings.Contains(str, expect) {
+			t.Errorf("tab is not expanded to %q:\n%s", expect, str)
+		}
+	}
+}
+
+//control characters shown as escapes
+func TestEscapeControl(t *testing.T) {
+	raw := RawString("bell\a line\nbreak \x1b[0m \xff \u0085")
+
+	str := Format(raw, func(o *Options) { o.EscapeControl = true })
+	fmt.Print(str)
+	expect := `bell\a line\nbreak \x1b[0m \xff \u0085`
+	if !strings.Contains(str, expect) {
+		t.Errorf("expect %s in:\n%s", expect, str)
+	}
+}
+
+//raw cells inside struct
+func TestRawCells(t *testing.T) {
+	type Note struct {
+		Title string
+		Body  string `table:",,raw"`
+		Code  RawString
+	}
+	notes := []Note{{"a\tb", "line1\nline2", "_"}}
+
+	str := Format(notes)
+	fmt.Print(str)
+	for _, expect := range []string{"│ 1 │  a b  │ line1 │  _   │", "│   │       │ line2 │      │"} {
+		if !strings.Contains(str, expect) {
+			t.Errorf("expect %q in:\n%s", expect, str)
+		}
+	}
+
+	str = Format(notes[0])
+	fmt.Print(str)
+	if !strings.Contains(str, "│ Body  │ line1 │\n│       │ line2 │") {
+		t.Errorf("raw field is not multi-line:\n%s", str)
+	}
+}
+
+type job struct {
+	Name   string
+	Status int    `table:"State,method:StatusText"`
+	Owner  string `table:",method:OwnerName"`
+}
+
+func (this job) StatusText() string {
+	return [...]string{"pending", "done"}[this.Status]
+}
+
+func (this *job) OwnerName() string {
+	return "@" + this.Owner
+}
+
+//method tag with value and pointer receivers
+func TestMethodTag(t *testing.T) {
+	plain := func(o *Options) { o.UseBoard = false }
+	expected := "" +
+		"    Name   State   Owner \n" +
+		" 1   a    pending   @x   \n" +
+		" 2   b     done     @y   \n"
+	jobs := []job{{"a", 0, "x"}, {"b", 1, "y"}}
+	if str := Format(jobs, plain); str != expected {
+		t.Errorf("list:\n%q", str)
+	}
+
+	//map values are not addressable
+	str := Format(map[string]job{"k": jobs[1]}, plain)
+	if str != "    Name  State  Owner \n k   b    done    @y   \n" {
+		t.Errorf("map:\n%q", str)
+	}
+}
+
+//unit row under header
+func TestUnits(t *testing.T) {
+	type Request struct {
+		Path    string
+		Latency int `table:",,unit=ms"`
+		Size    int `table:",,unit=KB"`
+	}
+	reqs := []Request{{"/a", 12, 3}}
+
+	expected := "" +
+		"┌───┬──────┬─────────┬──────┐\n" +
+		"│   │ Path │ Latency │ Size │\n" +
+		"│   │      │   ms    │  B   │\n" +
+		"├───┼──────┼─────────┼──────┤\n" +
+		"│ 1 │  /a  │   12    │  3   │\n" +
+		"└───┴──────┴─────────┴──────┘\n"
+	if str := Format(reqs, WithUnits(map[string]string{"Size": "B"})); str != expected {
+		t.Errorf("units:\n%s", str)
+	}
+
+	html := FormatHTML(reqs)
+	if !strings.Contains(html, "<thead>\n<tr><th></th><th>Path</th><th>Latency</th><th>Size</th></tr>\n"+
+		"<tr><th></th><th></th><th>ms</th><th>KB</th></tr>\n</thead>") {
+		t.Errorf("html units:\n%s", html)
+	}
+
+	//no unit
+	if str := Format([]Request{}, WithColumns("Path")); strings.Count(str, "\n") != 3 {
+		t.Errorf("unit row without units:\n%s", str)
+	}
+}
+
+//message of table without data rows
+func TestEmptyText(t *testing.T) {
+	type Item struct {
+		Name string
+	}
+	empty := func(o *Options) { o.EmptyText = "no results found" }
+
+	expected := "" +
+		"┌──────────────────┐\n" +
+		"│ no results found │\n" +
+		"└──────────────────┘\n"
+	for _, obj := range []interface{}{[]Item{}, "", map[string]int{}} {
+		if str := Format(obj, empty); str != expected {
+			t.Errorf("%#v:\n%s", obj, str)
+		}
+	}
+
+	if str := Format([]Item{}, empty, func(o *Options) { o.EmptyBorder = false }); str != "no results found\n" {
+		t.Errorf("without border:\n%q", str)
+	}
+	if str := FormatHTML(nil, empty); str != "<table>\n<tbody>\n<tr><td>no results found</td></tr>\n</tbody>\n</table>\n" {
+		t.Errorf("html:\n%s", str)
+	}
+
+	//data rows
+	if str := Format([]Item{{"a"}}, empty); strings.Contains(str, "no results") {
+		t.Errorf("not empty:\n%s", str)
+	}
+}
+
+//empty texts of columns
+func TestEmptyValues(t *testing.T) {
+	type User struct {
+		Name  string      `table:",,empty=n/a"`
+		Age   interface{} `table:",,empty=-"`
+		Email string
+	}
+	users := []User{{"", nil, ""}, {"a", 3, "x"}}
+	plain := func(o *Options) { o.UseBoard = false }
+
+	expected := "" +
+		"    Name  Age  Email \n" +
+		" 1  n/a    -         \n" +
+		" 2   a     3     x   \n"
+	if str := Format(users, plain); str != expected {
+		t.Errorf("tags:\n%q", str)
+	}
+
+	expected = "" +
+		"    Name  Age  Email \n" +
+		" 1  n/a    -     ?   \n" +
+		" 2   a     3     x   \n"
+	if str := Format(users, plain, WithEmptyValues(map[string]string{"Email": "?"})); str != expected {
+		t.Errorf("option:\n%q", str)
+	}
+
+	//short rows of strings
+	if str := Format("a b\n1", plain, WithEmptyValues(map[string]string{"b": "-"})); str != " a  b \n 1  - \n" {
+		t.Errorf("short row:\n%q", str)
+	}
+}
+
+//overflow modes
+func TestOverflowMode(t *testing.T) {
+	data := "a b\n1 2 3 4 5"
+	mode := func(m OverflowMode) Option {
+		return func(o *Options) {
+			o.UseBoard = false
+			o.ColOverflowMode = m
+		}
+	}
+
+	cases := map[OverflowMode]string{
+		OverflowMerge:   " a     b    \n 1  2 3 4 5 \n",
+		OverflowDiscard: " a  b \n 1  2 \n",
+		OverflowWrap:    " a  b \n 1  2 \n 3  4 \n 5    \n",
+		OverflowMarker:  " a      b      \n 1  2 …+3 cols \n",
+	}
+	for m, expected := range cases {
+		if str := Format(data, mode(m)); str != expected {
+			t.Errorf("mode %d:\n%q", m, str)
+		}
+	}
+
+	//auto follows ColOverflow
+	if Format(data, mode(OverflowAuto)) != cases[OverflowMerge] {
+		t.Errorf("auto is not merge")
+	}
+}
+
+//bool styles
+func TestBoolText(t *testing.T) {
+	type Feature struct {
+		Name    string
+		Enabled bool
+	}
+	features := []Feature{{"a", true}, {"b", false}}
+	bools := func(s BoolStyle) Option {
+		return func(o *Options) {
+			o.UseBoard = false
+			o.BoolText = s
+		}
+	}
+
+	cases := map[BoolStyle]string{
+		{}:        "    Name  Enabled \n 1   a     true   \n 2   b     false  \n",
+		BoolCheck: "    Name  Enabled \n 1   a       ✓    \n 2   b       ✗    \n",
+		BoolOnOff: "    Name  Enabled \n 1   a      on    \n 2   b      off   \n",
+	}
+	for s, expected := range cases {
+		if str := Format(features, bools(s)); str != expected {
+			t.Errorf("style %v:\n%q", s, str)
+		}
+	}
+}
+
+//json tag
+func TestJSONTag(t *testing.T) {
+	type Config struct {
+		Name   string
+		Blob   string          `table:",,json"`
+		Raw    json.RawMessage `table:",,json=indent"`
+		Broken string          `table:",,json"`
+	}
+	config := []Config{{"a", `{ "k": [1, 2] }`, json.RawMessage(`{"x":1}`), "{oops"}}
+
+	expected := "" +
+		"    Name     Blob        Raw     Broken \n" +
+		" 1   a    {\"k\":[1,2]}     {      {oops  \n" +
+		"                         \"x\": 1         \n" +
+		"                          }             \n"
+	if str := Format(config, WithTheme(ThemePlain)); str != expected {
+		t.Errorf("json:\n%q", str)
+	}
+}
+
+//columns hidden without data
+func TestHideWhenEmpty(t *testing.T) {
+	type Task struct {
+		Name  string
+		Error string `table:",,hidewhenempty"`
+		Note  string `table:",,hidewhenempty,empty=-"`
+	}
+	plain := WithTheme(ThemePlain)
+
+	tasks := []Task{{"a", "", ""}, {"b", "", "-"}}
+	if str := Format(tasks, plain); str != "    Name \n 1   a   \n 2   b   \n" {
+		t.Errorf("hidden:\n%q", str)
+	}
+
+	tasks[1].Error = "timeout"
+	expected := "" +
+		"    Name   Error  \n" +
+		" 1   a            \n" +
+		" 2   b    timeout \n"
+	if str := Format(tasks, plain); str != expected {
+		t.Errorf("shown:\n%q", str)
+	}
+}
+
+func TestFooter(t *testing.T) {
+	type Order struct {
+		Item  string  `table:",,footer=count"`
+		Price float64 `table:",,footer=sum"`
+		Qty   int     `table:",,footer=avg"`
+		Date  string  `table:",,footer=last"`
+	}
+	orders := []Order{{"pen", 1.5, 2, "05-01"}, {"ink", 0.25, 3, "05-02"}, {"pad", 10, 3, ""}}
+
+	expected := "" +
+		"    Item  Price  Qty   Date  \n" +
+		" 1  pen    1.5    2    05-01 \n" +
+		" 2  ink   0.25    3    05-02 \n" +
+		" 3  pad    10     3          \n" +
+		"     3    11.75  2.67  05-02 \n"
+	if str := Format(orders, WithTheme(ThemePlain)); str != expected {
+		t.Errorf("plain:\n%q", str)
+	}
+	if str := Format(orders[:0], WithTheme(ThemePlain)); strings.Count(str, "\n") != 1 {
+		t.Errorf("no data:\n%q", str)
+	}
+	if str := FormatHTML(orders[:1]); !strings.Contains(str, "<tfoot>\n<tr><td></td><td>1</td><td>1.5</td><td>2</td><td>05-01</td></tr>\n</tfoot>") {
+		t.Errorf("html:\n%s", str)
+	}
+	if str := Format(orders[:1], WithTheme(ThemeCompact)); strings.Count(str, "─") == 0 || strings.Count(str, "\n") != 5 {
+		t.Errorf("line above footer:\n%s", str)
+	}
+}
+
+func TestAppendFormat(t *testing.T) {
+	obj := []int{1, 2}
+	dst := append(make([]byte, 0, 1024), "log: "...)
+	out := AppendFormat(dst, obj)
+	if string(out) != "log: "+Format(obj) {
+		t.Errorf("appended:\n%s", out)
+	}
+	if &out[0] != &dst[0] {
+		t.Errorf("dst is not reused")
+	}
+	if out := NewFormatter(WithTheme(ThemePlain)).AppendFormat(nil, obj); string(out) != " 1  1 \n 2  2 \n" {
+		t.Errorf("formatter:\n%q", out)
+	}
+	if out := AppendFormat([]byte("x"), obj, WithRenderer(TextRenderer)); string(out) != "x"+Format(obj) {
+		t.Errorf("renderer:\n%q", out)
+	}
+}
+
+//writer recording the largest write, failing after limit bytes when limit is set
+type chunkWriter struct {
+	bytes.Buffer
+	largest int
+	limit   int
+}
+
+func (this *chunkWriter) Write(p []byte) (int, error) {
+	if this.limit != 0 && this.Len()+len(p) > this.limit {
+		return 0, io.ErrShortWrite
+	}
+	if len(p) > this.largest {
+		this.largest = len(p)
+	}
+	return this.Buffer.Write(p)
+}
+
+func TestFprint(t *testing.T) {
+	rows := make([][]string, 5000)
+	for i := range rows {
+		rows[i] = []string{strconv.Itoa(i), strings.Repeat("x", 20)}
+	}
+
+	var w chunkWriter
+	if err := Fprint(&w, rows); err != nil {
+		t.Fatal(err)
+	}
+	if w.String() != Format(rows) {
+		t.Errorf("chunks differ from Format")
+	}
+	if w.largest > 2*chunkSize {
+		t.Errorf("largest write is %d bytes", w.largest)
+	}
+
+	if err := Fprint(&chunkWriter{limit: 100}, rows); err != io.ErrShortWrite {
+		t.Errorf("error: %v", err)
+	}
+
+	w.Reset()
+	if err := NewFormatter(WithTheme(ThemePlain)).Fprint(&w, []int{1}); err != nil || w.String() != " 1  1 \n" {
+		t.Errorf("formatter: %q %v", w.String(), err)
+	}
+}
+
+func TestDeterministic(t *testing.T) {
+	m := map[string]int{}
+	for i := 0; i < 20; i++ {
+		m[strconv.Itoa(i)] = i
+	}
+	det := func(o *Options) { o.Deterministic = true }
+	first := Format(m, det)
+	for i := 0; i < 5; i++ {
+		if str := Format(m, det); str != first {
+			t.Fatalf("changed:\n%s\n%s", first, str)
+		}
+	}
+	if i, j := strings.Index(first, " 10 "), strings.Index(first, " 9 "); i < 0 || j < i {
+		t.Errorf("sorted:\n%s", first)
+	}
+
+	n := 3
+	type S struct {
+		P *int
+		F func()
+	}
+	if str := Format([]S{{&n, nil}, {&n, func() {}}}, det, WithTheme(ThemePlain)); str != "    P    F    \n 1  3         \n 2  3  func() \n" {
+		t.Errorf("addresses:\n%q", str)
+	}
+
+	//environment is ignored
+	defer os.Setenv("NO_COLOR", os.Getenv("NO_COLOR"))
+	os.Setenv("NO_COLOR", "1")
+	var buf bytes.Buffer
+	if str := Format([]S{{&n, nil}}, det, WithTheme(ThemeLight), WithOutput(&buf, &ThemeASCII)); !strings.Contains(str, "\x1b") || !strings.Contains(str, "┌") {
+		t.Errorf("environment:\n%s", str)
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	type Comment struct {
+		User string
+		Text RawString
+	}
+	comments := []Comment{
+		{"\x1b[31mroot\x1b[0m", "hi\x1b]0;owned\x07\nthere"},
+		{"ad‮min", "\x1b]8;;http://evil\x1b\\click\x1b]8;;\x1b\\\r\x07"},
+		{"bad\xffbyte\u009b2J", "ok"},
+	}
+	opts := []Option{WithTheme(ThemePlain), func(o *Options) { o.Sanitize = true }}
+	expected := "" +
+		"      User    Text  \n" +
+		" 1    root     hi   \n" +
+		"              there \n" +
+		" 2   admin    click \n" +
+		" 3  bad�byte   ok   \n"
+	if str := Format(comments, opts...); str != expected {
+		t.Errorf("sanitized:\n%q", str)
+	}
+	if sanitize("plain\ttext\n") != "plain\ttext\n" {
+		t.Errorf("plain text changed")
+	}
+	if str := Format([]Link{{Text: "docs", URL: "http://x"}}, append(opts, func(o *Options) { o.Hyperlinks = true })...); strings.Contains(str, "\x1b") {
+		t.Errorf("hyperlink:\n%q", str)
+	}
+}
+
+func TestInputFormat(t *testing.T) {
+	plain := WithTheme(ThemePlain)
+	auto := func(o *Options) { o.Input = InputAuto }
+	cases := []struct {
+		in       string
+		expected string
+	}{
+		{`[{"b":1,"a":"x y"},{"a":"z"}]`, "     a   b \n 1  x y  1 \n 2   z     \n"},
+		{`{"b":2,"a":[1,2]}`, " a  [1 2] \n b    2   \n"},
+		{"name,age\nann,30\nbob,\n", " name  age \n ann   30  \n bob       \n"},
+		{"name\tcity\nann\tNew York\n", " name    city   \n ann   New York \n"},
+		{"level=info msg=\"a b\"\nlevel=warn\n", " level  msg \n info   a b \n warn       \n"},
+		{"a b\n1 2\n", " a  b \n 1  2 \n"},
+		{"Hello, world\nFoo, bar", " Hello  world \n  Foo    bar  \n"},
+	}
+	for _, c := range cases {
+		if str := Format(c.in, plain, auto); str != c.expected {
+			t.Errorf("%q:\n%q", c.in, str)
+		}
+	}
+
+	//text by default and custom separators
+	if str := Format("a,b\n1,2\n", plain); str != " a,b \n 1,2 \n" {
+		t.Errorf("text:\n%q", str)
+	}
+	if str := Format("a,b;1,2", plain, auto, func(o *Options) { o.RowSeparator = ";"; o.ColumnSeparator = "," }); str != " a  b \n 1  2 \n" {
+		t.Errorf("separators:\n%q", str)
+	}
+}
+
+//widths are tracked while rows are encoded, including merged and filled cells
+func TestEncodeWidths(t *testing.T) {
+	tb, err := Encode("a b\n_ wide\n1 2 34567")
+	if err != nil {
+		t.Fatal(err)
+	}
+	widths := make([]int, len(tb.Headers))
+	for _, row := range append([][]string{tb.Headers}, tb.Rows...) {
+		for col, cell := range row {
+			if w := len(cell); w > widths[col] {
+				widths[col] = w
+			}
+		}
+	}
+	if fmt.Sprint(tb.ColumnWidths) != fmt.Sprint(widths) || tb.ColumnWidths[1] != len("2 34567") {
+		t.Errorf("widths %v of cells %q, expected %v", tb.ColumnWidths, tb.Rows, widths)
+	}
+}
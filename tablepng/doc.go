@@ -0,0 +1,6 @@
+//Package tablepng rasterizes tables to png with the basic font of golang.org/x/image, such as
+//images for chat bots which can not show monospace text, for example:
+//	t, _ := table.Encode(pods)
+//	tablepng.Renderer.Render(t, w)
+//it needs golang.org/x/image and is built with -tags ximage, the table package does not depend on it
+package tablepng
@@ -0,0 +1,57 @@
+//go:build ximage
+// +build ximage
+
+package tablepng
+
+import (
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/fanzhidongyzby/table"
+)
+
+//blank pixels around table
+const margin = 8
+
+//png of text table, registered as "png"
+var Renderer table.Renderer = table.RendererFunc(func(t *table.Table, w io.Writer) error {
+	return png.Encode(w, Draw(t))
+})
+
+func init() {
+	table.RegisterRenderer("png", Renderer)
+}
+
+//text table drawn black on white with basicfont.Face7x13, borders and cells are ascii and styles are dropped
+//because the font has ascii glyphs only
+func Draw(t *table.Table, opts ...table.Option) *image.RGBA {
+	ascii := func(o *table.Options) {
+		o.NoColor, o.ASCIIOnly, o.LineEnding = true, true, "\n"
+	}
+	text := t.Format(append(opts, ascii)...)
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+
+	face := basicfont.Face7x13
+	width := 0
+	for _, line := range lines {
+		if len(line) > width {
+			width = len(line)
+		}
+	}
+	img := image.NewRGBA(image.Rect(0, 0, 2*margin+width*face.Advance, 2*margin+len(lines)*face.Height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	d := &font.Drawer{Dst: img, Src: image.Black, Face: face}
+	for i, line := range lines {
+		d.Dot = fixed.P(margin, margin+i*face.Height+face.Ascent)
+		d.DrawString(line)
+	}
+	return img
+}
@@ -0,0 +1,51 @@
+//go:build ximage
+// +build ximage
+
+package tablepng
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"golang.org/x/image/font/basicfont"
+
+	"github.com/fanzhidongyzby/table"
+)
+
+//size of png follows column widths of box theme, one border between columns and padding 1
+func TestRenderer(t *testing.T) {
+	type Pod struct {
+		Name   string
+		Status string
+	}
+	tb, err := table.Encode([]Pod{{"web-1", "Running"}, {"db", "Pending"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := Renderer.Render(tb, &buf); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chars := len(tb.ColumnWidths) + 1
+	for _, w := range tb.ColumnWidths {
+		chars += w + 2
+	}
+	//top, header and a line under every row
+	lines := 2*len(tb.Rows) + 3
+	face := basicfont.Face7x13
+	if b := img.Bounds(); b.Dx() != 2*margin+chars*face.Advance || b.Dy() != 2*margin+lines*face.Height {
+		t.Errorf("bounds %v of widths %v", b, tb.ColumnWidths)
+	}
+
+	//registered by name
+	if r, ok := table.RendererOf("png"); !ok || r == nil {
+		t.Errorf("png is not registered")
+	}
+}
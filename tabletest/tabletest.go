@@ -0,0 +1,113 @@
+//Package tabletest compares formatted tables with golden files, such as
+//	func TestReport(t *testing.T) {
+//		tabletest.AssertRendersAs(t, report, "testdata/report.golden", tabletest.TrimTrailingSpace())
+//	}
+//tables are formatted in Deterministic mode, run tests with TABLETEST_UPDATE=1 to write the golden files from the current output
+package tabletest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fanzhidongyzby/table"
+)
+
+//environment variable writing golden files instead of comparing them
+const UpdateEnv = "TABLETEST_UPDATE"
+
+//settings of a comparison
+type config struct {
+	opts       []table.Option
+	trimSpace  bool
+	lineEnding bool
+}
+
+//option of AssertRendersAs
+type Option func(*config)
+
+//format obj with table options, such as table.WithTheme(table.ThemeASCII)
+func WithOptions(opts ...table.Option) Option {
+	return func(this *config) {
+		this.opts = append(this.opts, opts...)
+	}
+}
+
+//same output on every run and machine, map keys are sorted and the environment is ignored
+func deterministic(o *table.Options) {
+	o.Deterministic = true
+}
+
+//ignore spaces at the end of lines, such as padding of the last column
+func TrimTrailingSpace() Option {
+	return func(this *config) {
+		this.trimSpace = true
+	}
+}
+
+//treat \r\n and \r as \n, such as golden files checked out on windows
+func NormalizeLineEndings() Option {
+	return func(this *config) {
+		this.lineEnding = true
+	}
+}
+
+//fail t when the format of obj differs from the golden file, both are normalized by opts,
+//obj is formatted in Deterministic mode, the golden file is written when TABLETEST_UPDATE is set
+func AssertRendersAs(t testing.TB, obj interface{}, goldenPath string, opts ...Option) {
+	t.Helper()
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	got := c.normalize(table.Format(obj, append(c.opts, deterministic)...))
+
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(goldenPath), 0755); err != nil {
+			t.Fatalf("tabletest: %v", err)
+		}
+		if err := ioutil.WriteFile(goldenPath, []byte(got), 0644); err != nil {
+			t.Fatalf("tabletest: %v", err)
+		}
+		return
+	}
+
+	data, err := ioutil.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("tabletest: %v, run with %s=1 to create it", err, UpdateEnv)
+		return
+	}
+	if want := c.normalize(string(data)); got != want {
+		t.Errorf("tabletest: output differs from %s %s\ngot:\n%s\nwant:\n%s", goldenPath, diffLine(got, want), got, want)
+	}
+}
+
+//normalize line endings and trailing spaces
+func (this *config) normalize(str string) string {
+	if this.lineEnding {
+		str = strings.Replace(str, "\r\n", "\n", -1)
+		str = strings.Replace(str, "\r", "\n", -1)
+	}
+	if this.trimSpace {
+		lines := strings.Split(str, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight(line, " \t")
+		}
+		str = strings.Join(lines, "\n")
+	}
+	return str
+}
+
+//first different line from 1
+func diffLine(got, want string) string {
+	a, b := strings.Split(got, "\n"), strings.Split(want, "\n")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return fmt.Sprintf("at line %d: %q != %q", i+1, a[i], b[i])
+		}
+	}
+	return fmt.Sprintf("in line count: %d != %d", len(a), len(b))
+}
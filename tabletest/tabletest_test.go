@@ -0,0 +1,92 @@
+package tabletest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fanzhidongyzby/table"
+)
+
+//testing.TB recording failures
+type recorder struct {
+	testing.TB
+	failed string
+}
+
+func (this *recorder) Helper() {}
+
+func (this *recorder) Errorf(format string, args ...interface{}) {
+	this.failed = fmt.Sprintf(format, args...)
+}
+
+func (this *recorder) Fatalf(format string, args ...interface{}) {
+	this.failed = fmt.Sprintf(format, args...)
+}
+
+type user struct {
+	Name string
+	Age  int
+}
+
+var users = []user{{"ann", 30}, {"bob", 4}}
+
+func TestAssertRendersAs(t *testing.T) {
+	AssertRendersAs(t, users, "testdata/users.golden", WithOptions(table.WithTheme(table.ThemeASCII)))
+
+	//golden file with trailing spaces dropped and windows line endings
+	dir, err := ioutil.TempDir("", "tabletest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "users.golden")
+	plain := table.Format(users, table.WithTheme(table.ThemePlain))
+	lines := strings.Split(plain, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	ioutil.WriteFile(path, []byte(strings.Join(lines, "\r\n")), 0644)
+
+	r := &recorder{TB: t}
+	AssertRendersAs(r, users, path, WithOptions(table.WithTheme(table.ThemePlain)))
+	if !strings.Contains(r.failed, "at line 1") {
+		t.Errorf("not normalized: %s", r.failed)
+	}
+
+	r = &recorder{TB: t}
+	AssertRendersAs(r, users, path, WithOptions(table.WithTheme(table.ThemePlain)), TrimTrailingSpace(), NormalizeLineEndings())
+	if r.failed != "" {
+		t.Errorf("normalized: %s", r.failed)
+	}
+
+	r = &recorder{TB: t}
+	AssertRendersAs(r, users, filepath.Join(dir, "missing.golden"))
+	if !strings.Contains(r.failed, UpdateEnv) {
+		t.Errorf("missing: %s", r.failed)
+	}
+
+	//update writes the golden file
+	os.Setenv(UpdateEnv, "1")
+	AssertRendersAs(t, users, filepath.Join(dir, "new", "users.golden"))
+	os.Unsetenv(UpdateEnv)
+	if data, _ := ioutil.ReadFile(filepath.Join(dir, "new", "users.golden")); string(data) != table.Format(users) {
+		t.Errorf("updated:\n%s", data)
+	}
+
+	//maps in the same order every time
+	scores := map[string]int{}
+	for i := 0; i < 20; i++ {
+		scores[fmt.Sprint("user", i)] = i
+	}
+	path = filepath.Join(dir, "scores.golden")
+	os.Setenv(UpdateEnv, "1")
+	AssertRendersAs(t, scores, path)
+	os.Unsetenv(UpdateEnv)
+	for i := 0; i < 5; i++ {
+		AssertRendersAs(t, scores, path)
+	}
+}
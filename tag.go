@@ -0,0 +1,193 @@
+package table
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//parsed struct field
+type fieldInfo struct {
+	index   int
+	name    string
+	typeTag string
+	list    bool
+	raw     bool
+	link    bool
+	spark   bool
+
+	//digits after the point of floats, -1 means the shortest text
+	prec int
+	//exponent of floats
+	notation Notation
+
+	//json content compacted or indented
+	json       bool
+	jsonIndent bool
+
+	//niladic method giving the value, pointer receiver or not
+	method    string
+	ptrMethod bool
+}
+
+//parsed struct type, shared by all the values of the type, do not modify
+type structInfo struct {
+	fields        []fieldInfo
+	detKeys       []string
+	absKeys       []string
+	detRaw        []bool
+	absRaw        []bool
+	convertable   bool
+	convertableV2 bool
+
+	//key -> field name -> value of key=value tags such as unit=ms, nil when there is none
+	params map[string]map[string]string
+}
+
+//struct type -> *structInfo
+var structCache sync.Map
+
+var convertableType = reflect.TypeOf((*Convertable)(nil)).Elem()
+
+var convertableV2Type = reflect.TypeOf((*ConvertableV2)(nil)).Elem()
+
+var rawStringType = reflect.TypeOf(RawString(""))
+
+//get parsed struct type from cache
+func getStructInfo(t reflect.Type) *structInfo {
+	if info, ok := structCache.Load(t); ok {
+		return info.(*structInfo)
+	}
+
+	info, _ := structCache.LoadOrStore(t, parseStruct(t))
+	return info.(*structInfo)
+}
+
+//parse fields and tags of struct type
+func parseStruct(t reflect.Type) *structInfo {
+	info := &structInfo{
+		detKeys:       []string{},
+		absKeys:       []string{},
+		convertable:   t.Implements(convertableType),
+		convertableV2: t.Implements(convertableV2Type),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		//unexported field can not be read
+		if field.PkgPath != "" {
+			continue
+		}
+
+		nameTag, typeTag, method, flags := parseTag(field.Tag.Get("table"))
+
+		//name tag
+		name := field.Name
+		if nameTag == "-" {
+			continue
+		} else if nameTag != "" {
+			name = nameTag
+		}
+
+		f := fieldInfo{
+			index:   i,
+			name:    name,
+			typeTag: typeTag,
+			list:    !flags["nolist"],
+			raw:     flags["raw"] || field.Type == rawStringType || flags["json=indent"],
+			link:    flags["link"],
+			spark:   flags["spark"],
+
+			json:       flags["json"] || flags["json=indent"],
+			jsonIndent: flags["json=indent"],
+		}
+		f.method, f.ptrMethod = findMethod(t, method)
+		f.prec = -1
+		for flag := range flags {
+			if strings.HasPrefix(flag, "prec=") {
+				if n, err := strconv.Atoi(flag[5:]); err == nil && n >= 0 {
+					f.prec = n
+				}
+			}
+			if strings.HasPrefix(flag, "notation=") {
+				f.notation = notationNames[flag[9:]]
+			}
+		}
+
+		//key=value tags, hidewhenempty is kept with them
+		if flags["hidewhenempty"] {
+			info.setParam("hidewhenempty", name, "")
+		}
+		for flag := range flags {
+			if i := strings.IndexByte(flag, '='); i > 0 {
+				info.setParam(flag[:i], name, flag[i+1:])
+			}
+		}
+		info.fields = append(info.fields, f)
+		info.detKeys = append(info.detKeys, name)
+		info.detRaw = append(info.detRaw, f.raw)
+		if f.list {
+			info.absKeys = append(info.absKeys, name)
+			info.absRaw = append(info.absRaw, f.raw)
+		}
+	}
+
+	return info
+}
+
+//niladic method of struct type returning a value, unknown method is ignored
+func findMethod(t reflect.Type, name string) (method string, ptr bool) {
+	if name == "" {
+		return "", false
+	}
+	m, ok := t.MethodByName(name)
+	if !ok {
+		m, ok = reflect.PtrTo(t).MethodByName(name)
+		ptr = true
+	}
+	if !ok || m.Type.NumIn() != 1 || m.Type.NumOut() == 0 {
+		return "", false
+	}
+	return name, ptr
+}
+
+//set value of key=value tag of field
+func (this *structInfo) setParam(key, name, value string) {
+	if this.params == nil {
+		this.params = map[string]map[string]string{}
+	}
+	if this.params[key] == nil {
+		this.params[key] = map[string]string{}
+	}
+	this.params[key][name] = value
+}
+
+//parse tag, process tag: `table:"-|<newName>[,<newType>|method:<name>][,<nolist>][,<raw>][,<link>][,<spark>][,unit=<unit>][,empty=<text>][,pad=<rune>][,width=<n>][,maxwidth=<n>][,json[=indent]][,hidewhenempty][,prec=<n>][,notation=auto|sci|plain]"`
+func parseTag(tag string) (nameTag, typeTag, method string, flags map[string]bool) {
+	//tokenize
+	values := strings.Split(tag, ",")
+	num := len(values)
+	if num > 0 {
+		nameTag = values[0]
+	}
+	if num > 1 {
+		typeTag = values[1]
+	}
+
+	flags = map[string]bool{}
+	for i := 1; i < num; i++ {
+		value := strings.TrimSpace(values[i])
+		if strings.HasPrefix(value, "method:") {
+			method = strings.TrimPrefix(value, "method:")
+			if i == 1 {
+				typeTag = ""
+			}
+		} else if i > 1 {
+			flags[value] = true
+		}
+	}
+
+	return nameTag, typeTag, method, flags
+}
@@ -0,0 +1,18 @@
+package table
+
+import (
+	"text/template"
+)
+
+//template funcs, {{table .Items}} formats with opts and {{mdtable .Items}} formats as markdown
+func FuncMap(opts ...Option) template.FuncMap {
+	f := NewFormatter(opts...)
+	return template.FuncMap{
+		"table": func(obj interface{}) string {
+			return f.Format(obj)
+		},
+		"mdtable": func(obj interface{}) string {
+			return f.Format(obj, WithTheme(ThemeMarkdown))
+		},
+	}
+}
@@ -0,0 +1,27 @@
+package table
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+//tables in text/template
+func TestFuncMap(t *testing.T) {
+	tpl := template.Must(template.New("report").Funcs(FuncMap(func(o *Options) { o.UseBoard = false })).Parse(
+		"# Items\n{{table .}}\n{{mdtable .}}"))
+
+	var buf strings.Builder
+	if err := tpl.Execute(&buf, map[string]int{"a": 1}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "# Items\n a  1 \n\n|   |   |\n|---|---|\n| a | 1 |\n"
+	if buf.String() != expected {
+		t.Errorf("report:\n%q", buf.String())
+	}
+
+	buf.Reset()
+	if err := tpl.Execute(&buf, map[string]string{"a|b": "x|y"}); err != nil || !strings.HasSuffix(buf.String(), "| a\\|b | x\\|y |\n") {
+		t.Errorf("pipes %v:\n%q", err, buf.String())
+	}
+}
@@ -0,0 +1,223 @@
+package table
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+//alignment of cells in column, AlignDefault of a column keeps the theme's alignment
+//and AlignDefault of a theme centers
+type Align int
+
+const (
+	AlignDefault Align = iota
+	AlignCenter
+	AlignLeft
+	AlignRight
+)
+
+//SGR parameters of terminal style, such as "1" for bold and "1;36" for bold cyan,
+//empty style means no escape sequence
+type Style string
+
+//wrap str with style
+func (this Style) wrap(str string) string {
+	if this == "" || str == "" {
+		return str
+	}
+	return "\x1b[" + string(this) + "m" + str + "\x1b[0m"
+}
+
+//characters of table lines, lines without Horizontal character are not drawn
+type Border struct {
+	Horizontal string
+	Vertical   string
+
+	TopLeft   string
+	TopCenter string
+	TopRight  string
+
+	MiddleLeft   string
+	MiddleCenter string
+	MiddleRight  string
+
+	BottomLeft   string
+	BottomCenter string
+	BottomRight  string
+
+	//draw top and bottom lines
+	Frame bool
+	//draw left and right sides
+	Sides bool
+	//draw lines between data rows, the line under header is always drawn
+	RowLines bool
+	//always draw a header row, blank when the table has none
+	Header bool
+}
+
+//predefined borders
+var (
+	//no line at all
+	NoBorder = Border{}
+
+	//utf8 box drawing ┌─┬─┐
+	LightBorder = Border{
+		Horizontal: "─", Vertical: "│",
+		TopLeft: "┌", TopCenter: "┬", TopRight: "┐",
+		MiddleLeft: "├", MiddleCenter: "┼", MiddleRight: "┤",
+		BottomLeft: "└", BottomCenter: "┴", BottomRight: "┘",
+		Frame: true, Sides: true, RowLines: true,
+	}
+
+	//utf8 heavy box drawing ┏━┳━┓
+	HeavyBorder = Border{
+		Horizontal: "━", Vertical: "┃",
+		TopLeft: "┏", TopCenter: "┳", TopRight: "┓",
+		MiddleLeft: "┣", MiddleCenter: "╋", MiddleRight: "┫",
+		BottomLeft: "┗", BottomCenter: "┻", BottomRight: "┛",
+		Frame: true, Sides: true, RowLines: true,
+	}
+
+	//inner lines only, a rule under header
+	CompactBorder = Border{
+		Horizontal: "─", Vertical: "│",
+		MiddleCenter: "┼",
+	}
+
+	//ascii characters +-+ for terminals and fonts without box drawing
+	ASCIIBorder = Border{
+		Horizontal: "-", Vertical: "|",
+		TopLeft: "+", TopCenter: "+", TopRight: "+",
+		MiddleLeft: "+", MiddleCenter: "+", MiddleRight: "+",
+		BottomLeft: "+", BottomCenter: "+", BottomRight: "+",
+		Frame: true, Sides: true, RowLines: true,
+	}
+
+	//markdown pipe table
+	MarkdownBorder = Border{
+		Horizontal: "-", Vertical: "|",
+		MiddleLeft: "|", MiddleCenter: "|", MiddleRight: "|",
+		Sides: true, Header: true,
+	}
+)
+
+//bundle of border, styles, alignment and padding
+type Theme struct {
+	Border Border
+	Align  Align
+	//blanks on both sides of cell
+	Padding int
+	//minimum column width including padding
+	MinWidth int
+
+	HeaderStyle Style
+	BorderStyle Style
+	CellStyle   Style
+}
+
+//predefined themes
+var (
+	//no border, the style of UseBoard = false
+	ThemePlain = Theme{Border: NoBorder, Padding: 1}
+
+	//utf8 box, the style of UseBoard = true
+	ThemeBox = Theme{Border: LightBorder, Padding: 1}
+
+	//utf8 box with bold blue header for light terminal background
+	ThemeLight = Theme{Border: LightBorder, Padding: 1, HeaderStyle: "1;34"}
+
+	//heavy box with bright header and dim lines for dark terminal background
+	ThemeDark = Theme{Border: HeavyBorder, Padding: 1, HeaderStyle: "1;96", BorderStyle: "90"}
+
+	//inner lines only, left aligned
+	ThemeCompact = Theme{Border: CompactBorder, Align: AlignLeft, Padding: 1}
+
+	//ascii box for pipes, files and old consoles
+	ThemeASCII = Theme{Border: ASCIIBorder, Padding: 1}
+
+	//markdown pipe table, left aligned
+	ThemeMarkdown = Theme{Border: MarkdownBorder, Align: AlignLeft, Padding: 1, MinWidth: 3}
+)
+
+//named themes
+var Themes = map[string]Theme{
+	"plain":    ThemePlain,
+	"box":      ThemeBox,
+	"light":    ThemeLight,
+	"dark":     ThemeDark,
+	"compact":  ThemeCompact,
+	"ascii":    ThemeASCII,
+	"markdown": ThemeMarkdown,
+}
+
+//use theme
+func WithTheme(theme Theme) Option {
+	return func(this *Options) {
+		this.Theme = &theme
+	}
+}
+
+//use named theme, unknown name is ignored
+func WithThemeName(name string) Option {
+	return func(this *Options) {
+		if theme, ok := Themes[name]; ok {
+			this.Theme = &theme
+		}
+	}
+}
+
+//theme of call, UseBoard decides when there is no theme, styles are dropped by NoColor
+func (this *state) theme() *Theme {
+	theme := &ThemePlain
+	if this.Theme != nil {
+		theme = this.Theme
+	} else if this.UseBoard {
+		theme = &ThemeBox
+	}
+
+	if (this.LegacyConsole || this.ASCIIOnly) && !isASCII(theme.Border) {
+		//same lines of ascii characters
+		legacy, b := *theme, theme.Border
+		legacy.Border = ASCIIBorder
+		legacy.Border.Frame, legacy.Border.Sides, legacy.Border.RowLines, legacy.Border.Header = b.Frame, b.Sides, b.RowLines, b.Header
+		theme = &legacy
+	}
+
+	if this.noColor() {
+		plain := *theme
+		plain.HeaderStyle, plain.BorderStyle, plain.CellStyle = "", "", ""
+		return &plain
+	}
+	return theme
+}
+
+//escape | of cells as \| for markdown, widths of their columns grow
+func (this *state) escapePipes(line []string, colWidth []int) {
+	for col, val := range line {
+		if !strings.Contains(val, "|") {
+			continue
+		}
+		line[col] = strings.Replace(val, "|", `\|`, -1)
+		if size := this.cellWidth(line[col]); size > colWidth[col] {
+			colWidth[col] = size
+		}
+	}
+}
+
+//drop colors for NoColor and LegacyConsole
+func (this *state) noColor() bool {
+	return this.NoColor || this.LegacyConsole
+}
+
+//border of ascii characters
+func isASCII(b Border) bool {
+	for _, s := range []string{b.Horizontal, b.Vertical, b.TopLeft, b.TopCenter, b.TopRight,
+		b.MiddleLeft, b.MiddleCenter, b.MiddleRight, b.BottomLeft, b.BottomCenter, b.BottomRight} {
+		for i := 0; i < len(s); i++ {
+			if s[i] >= utf8.RuneSelf {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,228 @@
+package table
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+//predefined themes and the default theme
+func TestTheme(t *testing.T) {
+	type Person struct {
+		Name string
+		Age  int
+	}
+	people := []Person{{"alice", 30}, {"bob", 4}}
+
+	//no theme keeps the board
+	if Format(people) != Format(people, WithTheme(ThemeBox)) {
+		t.Errorf("default theme is not box")
+	}
+	if Format(people, func(o *Options) { o.UseBoard = false }) != Format(people, WithTheme(ThemePlain)) {
+		t.Errorf("plain theme is not UseBoard = false")
+	}
+
+	md := "" +
+		"|   | Name  | Age |\n" +
+		"|---|-------|-----|\n" +
+		"| 1 | alice | 30  |\n" +
+		"| 2 | bob   | 4   |\n"
+	if str := Format(people, WithThemeName("markdown")); str != md {
+		t.Errorf("markdown:\n%s", str)
+	}
+
+	//markdown needs a header
+	md = "" +
+		"|   |   |\n" +
+		"|---|---|\n" +
+		"| a | b |\n"
+	if str := Format("_ _\na b", WithTheme(ThemeMarkdown)); str != md {
+		t.Errorf("headless markdown:\n%s", str)
+	}
+
+	//pipes of cells are escaped
+	md = "" +
+		"|   | a\\|b |\n" +
+		"|---|------|\n" +
+		"| 1 | x\\|y |\n"
+	if str := Format([]map[string]string{{"a|b": "x|y"}}, WithTheme(ThemeMarkdown)); str != md {
+		t.Errorf("pipes:\n%s", str)
+	}
+	if str := Format([]map[string]string{{"a|b": "x|y"}}, WithTheme(ThemePlain)); !strings.Contains(str, " x|y ") {
+		t.Errorf("plain pipes:\n%s", str)
+	}
+
+	compact := "" +
+		"   │ Name  │ Age \n" +
+		"───┼───────┼─────\n" +
+		" 1 │ alice │ 30  \n" +
+		" 2 │ bob   │ 4   \n"
+	if str := Format(people, WithTheme(ThemeCompact)); str != compact {
+		t.Errorf("compact:\n%s", str)
+	}
+
+	//styles are escapes only
+	dark := Format(people, WithThemeName("dark"))
+	if !strings.Contains(dark, "\x1b[1;96mName\x1b[0m") || strings.Contains(dark, "\x1b[1;96malice") {
+		t.Errorf("header style expected:\n%q", dark)
+	}
+	if stripEscapes(dark) != strings.NewReplacer("─", "━", "│", "┃", "┌", "┏", "┬", "┳", "┐", "┓",
+		"├", "┣", "┼", "╋", "┤", "┫", "└", "┗", "┴", "┻", "┘", "┛").Replace(Format(people)) {
+		t.Errorf("dark layout:\n%s", dark)
+	}
+
+	//right alignment with padding
+	theme := ThemePlain
+	theme.Align = AlignRight
+	theme.Padding = 2
+	if str := Format("a bb\nccc d", WithTheme(theme)); str != "    a    bb  \n  ccc     d  \n" {
+		t.Errorf("right:\n%q", str)
+	}
+}
+
+//lines between sections
+func TestSections(t *testing.T) {
+	type Host struct {
+		Zone string
+		Name string
+	}
+	hosts := []Host{{"a", "x"}, {"a", "y"}, {"b", "z"}}
+	compact := WithTheme(ThemeCompact)
+
+	expected := "" +
+		"   │ Zone │ Name \n" +
+		"───┼──────┼──────\n" +
+		" 1 │ a    │ x    \n" +
+		" 2 │ a    │ y    \n" +
+		"───┼──────┼──────\n" +
+		" 3 │ b    │ z    \n"
+	if str := Format(hosts, compact, WithSectionsBy("Zone")); str != expected {
+		t.Errorf("sections by zone:\n%s", str)
+	}
+	if str := Format(hosts, compact, WithSeparatorsAfter(1, 5)); str != expected {
+		t.Errorf("separators after:\n%s", str)
+	}
+}
+
+//padding runes of columns
+func TestPadRunes(t *testing.T) {
+	type Chapter struct {
+		Title string `table:",,pad=."`
+		Page  int    `table:",,pad=・"`
+	}
+	toc := []Chapter{{"Intro", 1}, {"Usage", 12}}
+	theme := ThemePlain
+	theme.Align = AlignLeft
+
+	str := Format(toc, WithTheme(theme), WithPadRunes(map[string]rune{"Page": '.'}), WithColumns("Title", "Page"))
+	expected := "" +
+		"    Title  Page \n" +
+		" 1  Intro  1... \n" +
+		" 2  Usage  12.. \n"
+	if str != expected {
+		t.Errorf("option:\n%q", str)
+	}
+
+	//wide rune leaves a blank
+	expected = "" +
+		"    Title  Page \n" +
+		" 1  Intro  1・  \n" +
+		" 2  Usage  12・ \n"
+	if str := Format(toc, WithTheme(theme)); str != expected {
+		t.Errorf("tag:\n%q", str)
+	}
+}
+
+//styles are dropped for pipes
+func TestWithOutput(t *testing.T) {
+	var buf bytes.Buffer
+	if IsTerminal(&buf) {
+		t.Errorf("buffer is a terminal")
+	}
+
+	type Row struct {
+		Name  string
+		Value int
+	}
+	rows := []Row{{"a", 1}, {"b", 2}}
+	str := Format(rows, WithTheme(ThemeLight), WithHeatmap("Value"), WithOutput(&buf, &ThemeASCII))
+	expected := "" +
+		"+---+------+-------+\n" +
+		"|   | Name | Value |\n" +
+		"+---+------+-------+\n" +
+		"| 1 |  a   |   1   |\n" +
+		"+---+------+-------+\n" +
+		"| 2 |  b   |   2   |\n" +
+		"+---+------+-------+\n"
+	if str != expected {
+		t.Errorf("piped:\n%s", str)
+	}
+
+	if str := Format(rows, WithTheme(ThemeLight), WithOutput(&buf, nil)); strings.Contains(str, "\x1b") {
+		t.Errorf("colors:\n%q", str)
+	}
+}
+
+//color environment variables
+func TestColorEnv(t *testing.T) {
+	defer os.Setenv("NO_COLOR", os.Getenv("NO_COLOR"))
+	defer os.Setenv("FORCE_COLOR", os.Getenv("FORCE_COLOR"))
+	var buf bytes.Buffer
+	colored := func(opts ...Option) bool {
+		return strings.Contains(Format([]struct{ Name string }{{"a"}}, append([]Option{WithTheme(ThemeLight)}, opts...)...), "\x1b")
+	}
+
+	os.Setenv("NO_COLOR", "")
+	os.Setenv("FORCE_COLOR", "")
+	if !colored() || colored(WithOutput(&buf, nil)) {
+		t.Errorf("colors without environment")
+	}
+
+	os.Setenv("NO_COLOR", "1")
+	if colored() {
+		t.Errorf("colors with NO_COLOR")
+	}
+
+	os.Setenv("FORCE_COLOR", "1")
+	if !colored() || !colored(WithOutput(&buf, nil)) {
+		t.Errorf("no colors with FORCE_COLOR")
+	}
+}
+
+//ascii lines for old consoles
+func TestLegacyConsole(t *testing.T) {
+	legacy := func(o *Options) { o.LegacyConsole = true }
+	rows := []struct{ Name string }{{"a"}}
+
+	expected := "" +
+		"+---+------+\n" +
+		"|   | Name |\n" +
+		"+---+------+\n" +
+		"| 1 |  a   |\n" +
+		"+---+------+\n"
+	if str := Format(rows, WithTheme(ThemeDark), legacy); str != expected {
+		t.Errorf("dark:\n%q", str)
+	}
+	if str := Format(rows, WithTheme(ThemeCompact), legacy); str != "   | Name \n---+------\n 1 | a    \n" {
+		t.Errorf("compact:\n%q", str)
+	}
+	if Format(rows, WithTheme(ThemeMarkdown), legacy) != Format(rows, WithTheme(ThemeMarkdown)) {
+		t.Errorf("markdown is changed")
+	}
+}
+
+func TestLineEnding(t *testing.T) {
+	crlf := func(o *Options) { o.LineEnding = "\r\n" }
+	rows := [][]string{{"a", "b"}, {"1", "x\ny"}}
+	str := Format(rows, crlf, func(o *Options) { o.RowSeparator = "" })
+	if strings.Replace(str, "\r\n", "\n", -1) != Format(rows, func(o *Options) { o.RowSeparator = "" }) || strings.Count(str, "\r\n") != strings.Count(str, "\n") {
+		t.Errorf("crlf:\n%q", str)
+	}
+	if str := Format([]int{}, crlf, func(o *Options) { o.EmptyText = "none"; o.EmptyBorder = false }); str != "none\r\n" {
+		t.Errorf("empty: %q", str)
+	}
+	if str := Format(rows, crlf, WithRenderer(CSVRenderer)); !strings.HasSuffix(str, "\r\n") {
+		t.Errorf("csv: %q", str)
+	}
+}
@@ -0,0 +1,18 @@
+package table
+
+import (
+	"fmt"
+)
+
+//format a title line of format and args like fmt.Sprintf and the table of obj,
+//such as Formatf(list, "Deployments in %s", []interface{}{ns}, WithSort("Name"))
+func Formatf(obj interface{}, format string, args []interface{}, opts ...Option) string {
+	return NewFormatter().Formatf(obj, format, args, opts...)
+}
+
+//format a title line and the table with the formatter's options
+func (this *Formatter) Formatf(obj interface{}, format string, args []interface{}, opts ...Option) string {
+	s := newState(this.options, opts)
+	title := s.theme().HeaderStyle.wrap(fmt.Sprintf(format, args...))
+	return title + s.newline() + s.run(obj)
+}
@@ -0,0 +1,40 @@
+package table
+
+import (
+	"testing"
+)
+
+func TestFormatf(t *testing.T) {
+	type Deployment struct {
+		Name     string
+		Replicas int
+	}
+	list := []Deployment{{"web", 3}, {"api", 2}}
+	plain := WithTheme(ThemePlain)
+	noColor := func(this *Options) {
+		this.NoColor = true
+	}
+
+	//title args and options
+	expected := "" +
+		"Deployments in prod\n" +
+		"    Name  Replicas \n" +
+		" 2  api      2     \n" +
+		" 1  web      3     \n"
+	if str := Formatf(list, "Deployments in %s", []interface{}{"prod"}, plain, noColor, WithSort("Name")); str != expected {
+		t.Errorf("formatf:\n%q", str)
+	}
+
+	//styled title like sub-tables
+	bold := ThemePlain
+	bold.HeaderStyle = "1"
+	f := NewFormatter(WithTheme(bold))
+	if str := f.Formatf(list[:1], "%d deployments", []interface{}{len(list)}); str != "\x1b[1m2 deployments\x1b[0m\n    \x1b[1mName\x1b[0m  \x1b[1mReplicas\x1b[0m \n 1  web      3     \n" {
+		t.Errorf("formatter:\n%q", str)
+	}
+
+	//struct args fill the title, not the table
+	if str := Formatf(list[:1], "%v", []interface{}{list[1]}, plain); str != "{api 2}\n    Name  Replicas \n 1  web      3     \n" {
+		t.Errorf("struct arg:\n%q", str)
+	}
+}
@@ -0,0 +1,72 @@
+package table
+
+import (
+	"io"
+	"os"
+)
+
+//w is a terminal, such as os.Stdout which is not redirected
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+//writer of WithOutput
+type output struct {
+	w     io.Writer
+	piped *Theme
+}
+
+//degrade output to w which is not a terminal, such as a pipe or a file,
+//colors are dropped by NoColor unless FORCE_COLOR is set and piped theme is used when it is not nil, such as &ThemeASCII,
+//LegacyConsole is set for Windows consoles where escape sequences can not be enabled, nothing changes in Deterministic mode
+func WithOutput(w io.Writer, piped *Theme) Option {
+	return func(this *Options) {
+		this.output = &output{w, piped}
+	}
+}
+
+//NO_COLOR sets NoColor unless FORCE_COLOR is set, then output of WithOutput degrades options,
+//the environment is ignored in Deterministic mode
+func (this *Options) applyEnv() {
+	if this.Deterministic {
+		return
+	}
+	if noColorEnv() {
+		this.NoColor = true
+	}
+	if this.output == nil {
+		return
+	}
+
+	if IsTerminal(this.output.w) {
+		if f := this.output.w.(*os.File); isLegacyConsole(f) && !forceColorEnv() {
+			this.LegacyConsole = true
+		}
+		return
+	}
+	if !forceColorEnv() {
+		this.NoColor = true
+	}
+	if this.output.piped != nil {
+		this.Theme = this.output.piped
+	}
+}
+
+//NO_COLOR is set to a non-empty value and FORCE_COLOR is not, see no-color.org
+func noColorEnv() bool {
+	return os.Getenv("NO_COLOR") != "" && !forceColorEnv()
+}
+
+//FORCE_COLOR is set to a value other than 0 and false
+func forceColorEnv() bool {
+	switch os.Getenv("FORCE_COLOR") {
+	case "", "0", "false":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+// +build !windows
+
+package table
+
+import (
+	"os"
+)
+
+//terminals of other systems process escape sequences
+func isLegacyConsole(f *os.File) bool {
+	return false
+}
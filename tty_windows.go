@@ -0,0 +1,26 @@
+package table
+
+import (
+	"os"
+	"syscall"
+)
+
+//console mode showing escape sequences, since Windows 10
+const enableVirtualTerminalProcessing = 0x0004
+
+//syscall has GetConsoleMode but no SetConsoleMode
+var setConsoleMode = syscall.NewLazyDLL("kernel32.dll").NewProc("SetConsoleMode")
+
+//f is a console which does not process escape sequences and can not be switched to process them
+func isLegacyConsole(f *os.File) bool {
+	h := syscall.Handle(f.Fd())
+	var mode uint32
+	if err := syscall.GetConsoleMode(h, &mode); err != nil {
+		return false
+	}
+	if mode&enableVirtualTerminalProcessing != 0 {
+		return false
+	}
+	ok, _, _ := setConsoleMode.Call(uintptr(h), uintptr(mode|enableVirtualTerminalProcessing))
+	return ok == 0
+}
@@ -0,0 +1,51 @@
+package table
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//format the value of get every interval and rewrite it in place with cursor control, like watch(1),
+//return when ctx is done or writing fails
+func Watch(ctx context.Context, interval time.Duration, get func() interface{}, w io.Writer, opts ...Option) error {
+	return NewFormatter(opts...).Watch(ctx, interval, get, w)
+}
+
+//watch with the formatter's options
+func (this *Formatter) Watch(ctx context.Context, interval time.Duration, get func() interface{}, w io.Writer, opts ...Option) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lines := 0
+	for {
+		str, err := this.FormatContext(ctx, get(), opts...)
+		if err != nil {
+			return err
+		}
+		if _, err = io.WriteString(w, redraw(str, lines)); err != nil {
+			return err
+		}
+		lines = strings.Count(str, "\n")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+//move cursor up over the last frame, clear every line's tail and the rest of screen
+func redraw(str string, lines int) string {
+	var buf strings.Builder
+	buf.Grow(len(str) + strings.Count(str, "\n")*3 + 16)
+	if lines > 0 {
+		buf.WriteString("\x1b[" + strconv.Itoa(lines) + "A\r")
+	}
+	buf.WriteString(strings.Replace(str, "\n", "\x1b[K\n", -1))
+	buf.WriteString("\x1b[J")
+	return buf.String()
+}
@@ -0,0 +1,35 @@
+package table
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+//frames are redrawn in place until ctx is done
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	n := 0
+	get := func() interface{} {
+		n++
+		if n == 3 {
+			cancel()
+		}
+		return []int{n}
+	}
+
+	var buf bytes.Buffer
+	err := Watch(ctx, time.Millisecond, get, &buf, func(o *Options) { o.UseBoard = false })
+	if err != context.Canceled {
+		t.Errorf("canceled expected, got %v", err)
+	}
+
+	expected := "" +
+		" 1  1 \x1b[K\n\x1b[J" +
+		"\x1b[1A\r 1  2 \x1b[K\n\x1b[J"
+	if str := buf.String(); !strings.HasPrefix(str, expected) || strings.Count(str, "\x1b[J") > 3 {
+		t.Errorf("frames:\n%q", str)
+	}
+}
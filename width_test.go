@@ -0,0 +1,192 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+//display width of mixed language text
+func TestWidth(t *testing.T) {
+	s := newState(Defaults(), nil)
+	cases := map[string]int{
+		"abc":     3,
+		"你好":      4,
+		"café":    4,
+		"Ωμέγα":   5,
+		"Привет":  6,
+		"ｈｉ":      4,
+		"、。":      4,
+		"e\u0301": 1,
+		"한국어":     6,
+	}
+	for str, expect := range cases {
+		if w := s.width(str); w != expect {
+			t.Errorf("width(%q) = %d, expect %d", str, w, expect)
+		}
+	}
+
+	s.AmbiguousWidth = 2
+	if w := s.width("Ωμεγα"); w != 10 {
+		t.Errorf("ambiguous Greek width = %d, expect 10", w)
+	}
+}
+
+//borders align with ambiguous wide line characters
+func TestAmbiguousBoard(t *testing.T) {
+	str := Format("a bb\nccc d", func(o *Options) { o.AmbiguousWidth = 2 })
+	s := newState(Defaults(), []Option{func(o *Options) { o.AmbiguousWidth = 2 }})
+	lines := strings.Split(strings.TrimSpace(str), "\n")
+	for _, line := range lines {
+		if s.width(line) != s.width(lines[0]) {
+			t.Errorf("misaligned line %q:\n%s", line, str)
+		}
+	}
+}
+
+//grapheme clusters take the cells of one character
+func TestGraphemeWidth(t *testing.T) {
+	s := newState(Defaults(), nil)
+	cases := map[string]int{
+		"\U0001F44D":           2, //thumbs up
+		"\U0001F44D\U0001F3FD": 2, //skin tone
+		"\U0001F468\u200D\U0001F469\u200D\U0001F467": 2, //family
+		"\U0001F1E8\U0001F1F3":                       2, //flag
+		"\U0001F1E8\U0001F1F3\U0001F1FA\U0001F1F8":   4, //two flags
+		"\u2764\uFE0F":                               2, //emoji presentation
+		"1\uFE0F\u20E3":                              2, //keycap
+		"ok \u2705":                                  5,
+		"\u1100\u1161\u11A8":                         2, //conjoining jamo
+		"a\u0308\u0301":                              1, //combining marks
+		"\U0001F3F3\uFE0F\u200D\U0001F308 done":      7, //rainbow flag
+		"\U0001F3F4\U000E0067\U000E007F":             2, //tag sequence
+	}
+	for str, expect := range cases {
+		if w := s.width(str); w != expect {
+			t.Errorf("width(%q) = %d, expect %d", str, w, expect)
+		}
+	}
+}
+
+//escape sequences take no cells but are kept in output
+func TestEscapeWidth(t *testing.T) {
+	s := newState(Defaults(), nil)
+	red := "\x1b[1;31mred\x1b[0m"
+	link := "\x1b]8;;http://a.b\x1b\\site\x1b]8;;\x1b\\"
+	if w := s.width(red); w != 3 {
+		t.Errorf("width(%q) = %d, expect 3", red, w)
+	}
+	if w := s.width(link); w != 4 {
+		t.Errorf("width(%q) = %d, expect 4", link, w)
+	}
+	if str := stripEscapes(red + link); str != "redsite" {
+		t.Errorf("stripEscapes = %q", str)
+	}
+
+	str := Format("name color\napple " + red)
+	if !strings.Contains(str, "│ apple │  "+red+"  │") {
+		t.Errorf("colored cell misaligned:\n%s", str)
+	}
+}
+
+//width and maxwidth tags
+func TestWidthTags(t *testing.T) {
+	type Row struct {
+		ID   string `table:",,width=4"`
+		Note string `table:",,maxwidth=6"`
+		Tag  string `table:",,maxwidth=6"`
+	}
+	rows := []Row{{"a", "hello world", "x"}, {"abcdef", "你好世界", "\x1b[1mboldly\x1b[0m"}}
+	plain := func(o *Options) { o.UseBoard = false }
+
+	expected := "" +
+		"     ID    Note    Tag   \n" +
+		" 1   a    hello…    x    \n" +
+		" 2  abc…  你好…   \x1b[1mboldly\x1b[0m \n"
+	if str := Format(rows, plain); str != expected {
+		t.Errorf("widths:\n%q", str)
+	}
+	//styles are reset after the cut
+	s := newState(Defaults(), nil)
+	if str := s.truncate("\x1b[1mboldly\x1b[0m", 4); str != "\x1b[1mbol\x1b[0m…" {
+		t.Errorf("styled cut: %q", str)
+	}
+}
+
+//fixed column widths
+func TestFixedLayout(t *testing.T) {
+	plain := func(o *Options) { o.UseBoard = false }
+	fixed := WithFixedLayout(0, 5)
+
+	page1 := Format([][]string{{"a very long text"}}, fixed, plain)
+	page2 := Format([][]string{{"ab"}}, fixed, plain)
+	if page1 != " 1  [a v… \n" || page2 != " 1  [ab]  \n" {
+		t.Errorf("pages:\n%q\n%q", page1, page2)
+	}
+
+	type Note struct {
+		Name string
+		Text string
+	}
+	str := Format(Note{"x", "a very long text"}, WithFixedLayout(4, 6), plain, func(o *Options) { o.WrapCells = true })
+	expected := "" +
+		" Name    x    \n" +
+		" Text  a very \n" +
+		"        long  \n" +
+		"        text  \n"
+	if str != expected {
+		t.Errorf("wrap cells:\n%q", str)
+	}
+
+	//every column is limited, narrow ones keep their widths
+	if str := Format("a b\nxy 世界世界", WithMaxWidth(5), WithTheme(ThemePlain)); str != " a     b   \n xy  世界… \n" {
+		t.Errorf("max width:\n%q", str)
+	}
+
+	s := newState(Defaults(), nil)
+	if str := s.wrap("hello wide 世界世界", 5); str != "hello\nwide\n世界\n世界" {
+		t.Errorf("wrap: %q", str)
+	}
+}
+
+//cut in the middle
+func TestMiddleEllipsis(t *testing.T) {
+	s := newState(Defaults(), []Option{func(o *Options) { o.MiddleEllipsis = true }})
+	cases := map[string]string{
+		"/very/long/path/file.go": "/very…le.go",
+		"sha256:0123456789abcdef": "sha25…bcdef",
+		"你好世界你好世界":                "你好…世界",
+		"short":                   "short",
+	}
+	for str, expected := range cases {
+		if cut := s.fit(str, 11); cut != expected {
+			t.Errorf("cut %q: %q", str, cut)
+		}
+	}
+}
+
+func TestASCIIOnly(t *testing.T) {
+	if len(latinExtA) != 0x80 {
+		t.Fatalf("latin extended-a has %d letters", len(latinExtA))
+	}
+	if str := transliterate("Crème brûlée – Łódź “ŒUVRE” ▁▅█ 東京 é"); str != "Creme brulee - Lodz \"OEUVRE\" _=# ?? e" {
+		t.Errorf("transliterated: %q", str)
+	}
+
+	type City struct {
+		Name string `table:",,maxwidth=6"`
+		Ok   bool
+	}
+	ascii := func(o *Options) { o.ASCIIOnly, o.NoColor, o.BoolText = true, true, BoolCheck }
+	str := Format([]City{{"Zürich", true}, {"São Paulo", false}}, WithTheme(ThemeDark), ascii)
+	expected := "" +
+		"+---+--------+----+\n" +
+		"|   |  Name  | Ok |\n" +
+		"+---+--------+----+\n" +
+		"| 1 | Zurich | v  |\n" +
+		"+---+--------+----+\n" +
+		"| 2 | Sao... | x  |\n" +
+		"+---+--------+----+\n"
+	if str != expected {
+		t.Errorf("ascii table:\n%q", str)
+	}
+}